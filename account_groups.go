@@ -1,6 +1,9 @@
 package thousandeyes
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // AccountGroups - list of account groups
 type AccountGroups []AccountGroup
@@ -21,7 +24,12 @@ type SharedWithAccount struct {
 
 // GetAccountGroups - Get third party and webhook integrations
 func (c *Client) GetAccountGroups() (*[]SharedWithAccount, error) {
-	resp, err := c.get("/account-groups")
+	return c.GetAccountGroupsWithContext(context.Background())
+}
+
+// GetAccountGroupsWithContext - same as GetAccountGroups, using ctx for cancellation and deadlines
+func (c *Client) GetAccountGroupsWithContext(ctx context.Context) (*[]SharedWithAccount, error) {
+	resp, err := c.get(ctx, "/account-groups")
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -84,7 +85,12 @@ func (t *Agent) UnmarshalJSON(data []byte) error {
 
 // GetAgents - Get agents
 func (c *Client) GetAgents() (*Agents, error) {
-	resp, err := c.get("/agents")
+	return c.GetAgentsWithContext(context.Background())
+}
+
+// GetAgentsWithContext - same as GetAgents, using ctx for cancellation and deadlines
+func (c *Client) GetAgentsWithContext(ctx context.Context) (*Agents, error) {
+	resp, err := c.get(ctx, "/agents")
 	if err != nil {
 		return &Agents{}, err
 	}
@@ -98,7 +104,12 @@ func (c *Client) GetAgents() (*Agents, error) {
 
 // GetAgent - Get agent
 func (c *Client) GetAgent(id int64) (*Agent, error) {
-	resp, err := c.get(fmt.Sprintf("/agents/%d", id))
+	return c.GetAgentWithContext(context.Background(), id)
+}
+
+// GetAgentWithContext - same as GetAgent, using ctx for cancellation and deadlines
+func (c *Client) GetAgentWithContext(ctx context.Context, id int64) (*Agent, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/agents/%d", id))
 	if err != nil {
 		return nil, err
 	}
@@ -112,12 +123,17 @@ func (c *Client) GetAgent(id int64) (*Agent, error) {
 
 // AddAgentsToCluster - add agent to cluster
 func (c *Client) AddAgentsToCluster(cluster int, ids []int) (*[]Agent, error) {
-	resp, err := c.post(fmt.Sprintf("/agents/%d/add-to-cluster", cluster), ids, nil)
+	return c.AddAgentsToClusterWithContext(context.Background(), cluster, ids)
+}
+
+// AddAgentsToClusterWithContext - same as AddAgentsToCluster, using ctx for cancellation and deadlines
+func (c *Client) AddAgentsToClusterWithContext(ctx context.Context, cluster int, ids []int) (*[]Agent, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/agents/%d/add-to-cluster", cluster), ids, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to add agents to cluster, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to add agents to cluster")
 	}
 	var target map[string][]Agent
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -129,12 +145,17 @@ func (c *Client) AddAgentsToCluster(cluster int, ids []int) (*[]Agent, error) {
 
 // RemoveAgentsFromCluster - remove agent from cluster
 func (c *Client) RemoveAgentsFromCluster(cluster int, ids []int) (*[]Agent, error) {
-	resp, err := c.post(fmt.Sprintf("/agents/%d/remove-from-cluster", cluster), ids, nil)
+	return c.RemoveAgentsFromClusterWithContext(context.Background(), cluster, ids)
+}
+
+// RemoveAgentsFromClusterWithContext - same as RemoveAgentsFromCluster, using ctx for cancellation and deadlines
+func (c *Client) RemoveAgentsFromClusterWithContext(ctx context.Context, cluster int, ids []int) (*[]Agent, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/agents/%d/remove-from-cluster", cluster), ids, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to remove agents from cluster, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to remove agents from cluster")
 	}
 	var target map[string][]Agent
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
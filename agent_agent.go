@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -90,7 +91,12 @@ func (t *AgentAgent) AddAlertRule(id int64) {
 
 // GetAgentAgent - Get an agent to agent test
 func (c *Client) GetAgentAgent(id int64) (*AgentAgent, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetAgentAgentWithContext(context.Background(), id)
+}
+
+// GetAgentAgentWithContext - same as GetAgentAgent, using ctx for cancellation and deadlines
+func (c *Client) GetAgentAgentWithContext(ctx context.Context, id int64) (*AgentAgent, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &AgentAgent{}, err
 	}
@@ -103,12 +109,17 @@ func (c *Client) GetAgentAgent(id int64) (*AgentAgent, error) {
 
 // CreateAgentAgent - Create an agent to agent test
 func (c Client) CreateAgentAgent(t AgentAgent) (*AgentAgent, error) {
-	resp, err := c.post("/tests/agent-to-agent/new", t, nil)
+	return c.CreateAgentAgentWithContext(context.Background(), t)
+}
+
+// CreateAgentAgentWithContext - same as CreateAgentAgent, using ctx for cancellation and deadlines
+func (c Client) CreateAgentAgentWithContext(ctx context.Context, t AgentAgent) (*AgentAgent, error) {
+	resp, err := c.post(ctx, "/tests/agent-to-agent/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create agent test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create agent test")
 	}
 	var target map[string][]AgentAgent
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -119,24 +130,34 @@ func (c Client) CreateAgentAgent(t AgentAgent) (*AgentAgent, error) {
 
 // DeleteAgentAgent - delete agent to agent test
 func (c *Client) DeleteAgentAgent(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/agent-to-agent/%d/delete", id), nil, nil)
+	return c.DeleteAgentAgentWithContext(context.Background(), id)
+}
+
+// DeleteAgentAgentWithContext - same as DeleteAgentAgent, using ctx for cancellation and deadlines
+func (c *Client) DeleteAgentAgentWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/agent-to-agent/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete agent test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete agent test")
 	}
 	return nil
 }
 
 // UpdateAgentAgent - update agent to agent test
 func (c *Client) UpdateAgentAgent(id int64, t AgentAgent) (*AgentAgent, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/agent-to-agent/%d/update", id), t, nil)
+	return c.UpdateAgentAgentWithContext(context.Background(), id, t)
+}
+
+// UpdateAgentAgentWithContext - same as UpdateAgentAgent, using ctx for cancellation and deadlines
+func (c *Client) UpdateAgentAgentWithContext(ctx context.Context, id int64, t AgentAgent) (*AgentAgent, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/agent-to-agent/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update agent test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update agent test")
 	}
 	var target map[string][]AgentAgent
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
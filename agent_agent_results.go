@@ -0,0 +1,126 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Directions of agent to agent results
+const (
+	DirectionSourceToTarget = "SOURCE_TO_TARGET"
+	DirectionTargetToSource = "TARGET_TO_SOURCE"
+)
+
+// AgentAgentResults - results of an agent to agent test
+type AgentAgentResults struct {
+	Test    GenericTest
+	Results []AgentAgentResult
+}
+
+// AgentAgentResult - network metrics between an agent and the target
+// agent in one direction in a round.  Loss is a percentage, latencies
+// and Jitter are in milliseconds and Throughput in bits per second.
+type AgentAgentResult struct {
+	AgentID         *int64               `json:"agentId,omitempty"`
+	AgentName       *string              `json:"agentName,omitempty"`
+	CountryID       *string              `json:"countryId,omitempty"`
+	TargetAgentID   *int64               `json:"targetAgentId,omitempty"`
+	TargetAgentName *string              `json:"targetAgentName,omitempty"`
+	Direction       *string              `json:"direction,omitempty"`
+	Date            *string              `json:"date,omitempty"`
+	RoundID         *int64               `json:"roundId,omitempty"`
+	ServerIP        *string              `json:"serverIp,omitempty"`
+	Loss            *float64             `json:"loss,omitempty"`
+	MinLatency      *float64             `json:"minLatency,omitempty"`
+	AvgLatency      *float64             `json:"avgLatency,omitempty"`
+	MaxLatency      *float64             `json:"maxLatency,omitempty"`
+	Jitter          *float64             `json:"jitter,omitempty"`
+	Throughput      *float64             `json:"throughput,omitempty"`
+	ErrorDetails    *[]AgentErrorDetails `json:"errorDetails,omitempty"`
+	Permalink       *string              `json:"permalink,omitempty"`
+}
+
+// AgentAgentPair - the metrics of both directions between an agent and
+// the target agent in a round, nil for directions not measured
+type AgentAgentPair struct {
+	AgentID        int64
+	TargetAgentID  int64
+	RoundID        int64
+	SourceToTarget *AgentAgentResult
+	TargetToSource *AgentAgentResult
+}
+
+// Pairs - groups the results per agent, target agent and round, ordered
+// by round, agent and target agent
+func (r AgentAgentResults) Pairs() []AgentAgentPair {
+	type key struct{ agent, target, round int64 }
+	pairs := map[key]*AgentAgentPair{}
+	keys := []key{}
+	for i := range r.Results {
+		result := &r.Results[i]
+		k := key{int64Value(result.AgentID), int64Value(result.TargetAgentID), int64Value(result.RoundID)}
+		pair, ok := pairs[k]
+		if !ok {
+			pair = &AgentAgentPair{AgentID: k.agent, TargetAgentID: k.target, RoundID: k.round}
+			pairs[k] = pair
+			keys = append(keys, k)
+		}
+		switch stringValue(result.Direction) {
+		case DirectionSourceToTarget:
+			pair.SourceToTarget = result
+		case DirectionTargetToSource:
+			pair.TargetToSource = result
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].round != keys[j].round {
+			return keys[i].round < keys[j].round
+		}
+		if keys[i].agent != keys[j].agent {
+			return keys[i].agent < keys[j].agent
+		}
+		return keys[i].target < keys[j].target
+	})
+	sorted := make([]AgentAgentPair, 0, len(keys))
+	for _, k := range keys {
+		sorted = append(sorted, *pairs[k])
+	}
+	return sorted
+}
+
+// GetAgentAgentResults - Get the metrics of an agent to agent test in
+// each direction
+func (c *Client) GetAgentAgentResults(testID int64, opts *ResultsOptions) (*AgentAgentResults, error) {
+	return c.GetAgentAgentResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetAgentAgentResultsWithContext - same as GetAgentAgentResults, using ctx for cancellation and deadlines
+func (c *Client) GetAgentAgentResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*AgentAgentResults, error) {
+	results := AgentAgentResults{Results: []AgentAgentResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/net/metrics/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Net struct {
+				Test    GenericTest        `json:"test"`
+				Metrics []AgentAgentResult `json:"metrics"`
+			} `json:"net"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Net.Test
+		for _, r := range target.Net.Metrics {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,53 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetAgentAgentResults(t *testing.T) {
+	out := `{"net": {"test": {"testId": 1, "type": "agent-to-agent"}, "metrics": [
+		{"agentId": 10, "agentName": "Dallas", "targetAgentId": 20, "targetAgentName": "London",
+		 "direction": "SOURCE_TO_TARGET", "roundId": 100, "loss": 0, "minLatency": 70, "avgLatency": 72,
+		 "maxLatency": 75, "jitter": 1.2, "throughput": 95000000},
+		{"agentId": 10, "agentName": "Dallas", "targetAgentId": 20, "targetAgentName": "London",
+		 "direction": "TARGET_TO_SOURCE", "roundId": 100, "loss": 1.5, "avgLatency": 73, "throughput": 60000000},
+		{"agentId": 30, "targetAgentId": 20, "direction": "SOURCE_TO_TARGET", "roundId": 100, "loss": 100}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetAgentAgentResults(1, &ResultsOptions{Window: "1h"})
+	assert.Nil(t, err)
+	assert.Equal(t, AgentAgentResult{
+		AgentID:         Int64(10),
+		AgentName:       String("Dallas"),
+		TargetAgentID:   Int64(20),
+		TargetAgentName: String("London"),
+		Direction:       String(DirectionSourceToTarget),
+		RoundID:         Int64(100),
+		Loss:            Float64(0),
+		MinLatency:      Float64(70),
+		AvgLatency:      Float64(72),
+		MaxLatency:      Float64(75),
+		Jitter:          Float64(1.2),
+		Throughput:      Float64(95000000),
+	}, res.Results[0])
+
+	pairs := res.Pairs()
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, int64(10), pairs[0].AgentID)
+	assert.Equal(t, int64(20), pairs[0].TargetAgentID)
+	assert.Equal(t, Float64(95000000), pairs[0].SourceToTarget.Throughput)
+	assert.Equal(t, Float64(60000000), pairs[0].TargetToSource.Throughput)
+	assert.Equal(t, int64(30), pairs[1].AgentID)
+	assert.Equal(t, Float64(100), pairs[1].SourceToTarget.Loss)
+	assert.Nil(t, pairs[1].TargetToSource)
+}
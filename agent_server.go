@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -107,7 +108,12 @@ func (t *AgentServer) AddAlertRule(id int64) {
 
 // GetAgentServer - Get agent to server test
 func (c *Client) GetAgentServer(id int64) (*AgentServer, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetAgentServerWithContext(context.Background(), id)
+}
+
+// GetAgentServerWithContext - same as GetAgentServer, using ctx for cancellation and deadlines
+func (c *Client) GetAgentServerWithContext(ctx context.Context, id int64) (*AgentServer, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &AgentServer{}, err
 	}
@@ -125,12 +131,17 @@ func (c *Client) GetAgentServer(id int64) (*AgentServer, error) {
 
 // CreateAgentServer  - Create agent to server test
 func (c Client) CreateAgentServer(t AgentServer) (*AgentServer, error) {
-	resp, err := c.post("/tests/agent-to-server/new", t, nil)
+	return c.CreateAgentServerWithContext(context.Background(), t)
+}
+
+// CreateAgentServerWithContext - same as CreateAgentServer, using ctx for cancellation and deadlines
+func (c Client) CreateAgentServerWithContext(ctx context.Context, t AgentServer) (*AgentServer, error) {
+	resp, err := c.post(ctx, "/tests/agent-to-server/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create agent server, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create agent server")
 	}
 	var target map[string][]AgentServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -146,24 +157,34 @@ func (c Client) CreateAgentServer(t AgentServer) (*AgentServer, error) {
 
 // DeleteAgentServer  - Delete agent to server test
 func (c *Client) DeleteAgentServer(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/agent-to-server/%d/delete", id), nil, nil)
+	return c.DeleteAgentServerWithContext(context.Background(), id)
+}
+
+// DeleteAgentServerWithContext - same as DeleteAgentServer, using ctx for cancellation and deadlines
+func (c *Client) DeleteAgentServerWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/agent-to-server/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete agent server, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete agent server")
 	}
 	return nil
 }
 
 // UpdateAgentServer  - Update agent to server test
 func (c *Client) UpdateAgentServer(id int64, t AgentServer) (*AgentServer, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/agent-to-server/%d/update", id), t, nil)
+	return c.UpdateAgentServerWithContext(context.Background(), id, t)
+}
+
+// UpdateAgentServerWithContext - same as UpdateAgentServer, using ctx for cancellation and deadlines
+func (c *Client) UpdateAgentServerWithContext(ctx context.Context, id int64, t AgentServer) (*AgentServer, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/agent-to-server/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update agent server, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update agent server")
 	}
 	var target map[string][]AgentServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
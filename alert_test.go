@@ -265,3 +265,78 @@ func TestClient_DeleteAlertRuleStatusCode(t *testing.T) {
 	teardown()
 	assert.ErrorContains(t, err, "Response did not contain formatted error: %!s(<nil>). HTTP response code: 400")
 }
+
+func TestClient_GetAlerts(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/alerts.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "1d", r.URL.Query().Get("window"))
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(`{"alert": [
+				{"alertId": 1, "testId": 10, "active": 1, "ruleName": "Latency", "violationCount": 2,
+				 "agents": [{"agentId": 100, "agentName": "Dallas"}]}
+			], "pages": {"current": 1, "next": "https://api.thousandeyes.com/v6/alerts.json?window=1d&page=2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"alert": [
+			{"alertId": 2, "testId": 10, "active": 0, "dateEnd": "2022-03-01 11:00:00",
+			 "monitors": [{"monitorId": 5, "monitorName": "Seattle-3"}]}
+		], "pages": {"current": 2}}`))
+	})
+
+	res, err := client.GetAlerts(&AlertsOptions{Window: "1d"})
+	assert.Nil(t, err)
+	assert.Equal(t, &Alerts{
+		{
+			AlertID:        Int64(1),
+			TestID:         Int64(10),
+			Active:         Int(1),
+			RuleName:       String("Latency"),
+			ViolationCount: Int(2),
+			Agents:         &[]Agent{{AgentID: Int64(100), AgentName: String("Dallas")}},
+		},
+		{
+			AlertID:  Int64(2),
+			TestID:   Int64(10),
+			Active:   Int(0),
+			DateEnd:  String("2022-03-01 11:00:00"),
+			Monitors: &[]Monitor{{MonitorID: Int64(5), MonitorName: String("Seattle-3")}},
+		},
+	}, res)
+
+	res, err = client.GetAlerts(&AlertsOptions{Window: "1d", Active: Bool(false)})
+	assert.Nil(t, err)
+	assert.Len(t, *res, 1)
+	assert.Equal(t, Int64(2), (*res)[0].AlertID)
+
+	// Without a time range, the API only lists active alerts
+	_, err = client.GetAlerts(&AlertsOptions{Active: Bool(false)})
+	assert.EqualError(t, err, "cleared alerts require a Window or From")
+
+	res, err = client.GetAlerts(&AlertsOptions{Window: "1d", Active: Bool(true), MaxPages: 1})
+	assert.Nil(t, err)
+	assert.Len(t, *res, 1)
+	assert.True(t, (*res)[0].IsActive())
+}
+
+func TestClient_GetAlert(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/alerts/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(`{"alert": [{"alertId": 1, "active": 1, "ruleExpression": "((responseTime >= 500 ms))"}]}`))
+	})
+	mux.HandleFunc("/alerts/2.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"alert": []}`))
+	})
+
+	res, err := client.GetAlert(1)
+	assert.Nil(t, err)
+	assert.Equal(t, &Alert{AlertID: Int64(1), Active: Int(1), RuleExpression: String("((responseTime >= 500 ms))")}, res)
+
+	_, err = client.GetAlert(2)
+	assert.EqualError(t, err, "could not get alert 2")
+}
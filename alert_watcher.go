@@ -0,0 +1,247 @@
+package thousandeyes
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// AlertEvent - a change of alert state reported by an AlertWatcher: an
+// AlertRaised, AlertUpdated or AlertCleared
+type AlertEvent interface {
+	// EventAlert returns the alert as of the event
+	EventAlert() Alert
+}
+
+// AlertRaised - an alert became active
+type AlertRaised struct {
+	Alert Alert
+}
+
+// AlertUpdated - the violation count, agents or monitors of an active
+// alert changed
+type AlertUpdated struct {
+	Alert    Alert
+	Previous Alert
+}
+
+// AlertCleared - an alert is no longer active
+type AlertCleared struct {
+	Alert Alert
+}
+
+// EventAlert - Satisfying the AlertEvent interface
+func (e AlertRaised) EventAlert() Alert { return e.Alert }
+
+// EventAlert - Satisfying the AlertEvent interface
+func (e AlertUpdated) EventAlert() Alert { return e.Alert }
+
+// EventAlert - Satisfying the AlertEvent interface
+func (e AlertCleared) EventAlert() Alert { return e.Alert }
+
+// AlertStore - persists the active alerts seen by an AlertWatcher, so
+// that a restarted watcher only reports changes
+type AlertStore interface {
+	Load() (Alerts, error)
+	Save(Alerts) error
+}
+
+// FileAlertStore - AlertStore keeping the alerts in a JSON file.  A
+// missing file loads as no alerts.
+type FileAlertStore struct {
+	Path string
+}
+
+// Load - Satisfying the AlertStore interface
+func (s FileAlertStore) Load() (Alerts, error) {
+	alerts := Alerts{}
+	if err := loadJSONFile(s.Path, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// Save - Satisfying the AlertStore interface
+func (s FileAlertStore) Save(alerts Alerts) error {
+	return saveJSONFile(s.Path, alerts)
+}
+
+// AlertWatcher - polls the active alerts and reports how they change.
+// Without a stored state, every active alert is first reported as
+// raised.  An AlertWatcher is not safe for concurrent use.
+type AlertWatcher struct {
+	Client *Client
+	// Interval is the time between polls, a minute by default
+	Interval time.Duration
+	// Store, when set, persists the active alerts between restarts
+	Store AlertStore
+
+	active map[int64]Alert
+}
+
+// Run - polls until ctx is done or handle returns an error, passing the
+// alert changes to handle.  Failing polls are logged and retried at the
+// next interval.
+func (w *AlertWatcher) Run(ctx context.Context, handle func(AlertEvent) error) error {
+	return runEvery(ctx, w.Interval, func() error {
+		return w.Poll(ctx, handle)
+	})
+}
+
+// Watch - runs the watcher in the background, sending the alert changes
+// to the returned channel.  When watching stops, the error is sent to the
+// error channel and both channels are closed.
+func (w *AlertWatcher) Watch(ctx context.Context) (<-chan AlertEvent, <-chan error) {
+	events := make(chan AlertEvent)
+	errc := runInBackground(func() error {
+		return w.Run(ctx, func(e AlertEvent) error {
+			select {
+			case events <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}, func() { close(events) })
+	return events, errc
+}
+
+// Poll - polls the active alerts once, passing the changes since the
+// previous poll to handle.  Raised and updated alerts are passed in
+// alert ID order, then cleared ones.  It only returns an error when ctx is
+// done, the store fails, or handle fails, in which case the same changes
+// are passed again at the next poll.
+func (w *AlertWatcher) Poll(ctx context.Context, handle func(AlertEvent) error) error {
+	if w.active == nil {
+		w.active = map[int64]Alert{}
+		if w.Store != nil {
+			alerts, err := w.Store.Load()
+			if err != nil {
+				w.active = nil
+				return err
+			}
+			for _, a := range alerts {
+				w.active[int64Value(a.AlertID)] = a
+			}
+		}
+	}
+
+	alerts, err := w.Client.GetAlertsWithContext(ctx, &AlertsOptions{Active: Bool(true)})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		w.Client.logger().Error("Could not poll alerts", "error", err)
+		return nil
+	}
+
+	events := []AlertEvent{}
+	current := map[int64]Alert{}
+	for _, a := range *alerts {
+		id := int64Value(a.AlertID)
+		current[id] = a
+		previous, ok := w.active[id]
+		if !ok {
+			events = append(events, AlertRaised{Alert: a})
+		} else if alertChanged(previous, a) {
+			events = append(events, AlertUpdated{Alert: a, Previous: previous})
+		}
+	}
+	cleared := []int64{}
+	for id := range w.active {
+		if _, ok := current[id]; !ok {
+			cleared = append(cleared, id)
+		}
+	}
+	sort.Slice(cleared, func(i, j int) bool { return cleared[i] < cleared[j] })
+	for _, id := range cleared {
+		events = append(events, AlertCleared{Alert: w.clearedAlert(ctx, id)})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		_, iCleared := events[i].(AlertCleared)
+		_, jCleared := events[j].(AlertCleared)
+		if iCleared != jCleared {
+			return jCleared
+		}
+		return int64Value(events[i].EventAlert().AlertID) < int64Value(events[j].EventAlert().AlertID)
+	})
+
+	for _, e := range events {
+		if err := handle(e); err != nil {
+			return err
+		}
+	}
+	w.active = current
+	if w.Store == nil || len(events) == 0 {
+		return nil
+	}
+	return w.Store.Save(*alerts)
+}
+
+// clearedAlert returns the final state of a cleared alert, falling back on
+// its last known state when it cannot be fetched
+func (w *AlertWatcher) clearedAlert(ctx context.Context, id int64) Alert {
+	alert, err := w.Client.GetAlertWithContext(ctx, id)
+	if err == nil {
+		return *alert
+	}
+	w.Client.logger().Debug("Could not get cleared alert", "id", id, "error", err)
+	last := w.active[id]
+	last.Active = Int(0)
+	return last
+}
+
+// alertChanged reports whether the violation count, agents or monitors of
+// an alert changed
+func alertChanged(previous, current Alert) bool {
+	if intValue(previous.ViolationCount) != intValue(current.ViolationCount) {
+		return true
+	}
+	var prevAgents, curAgents, prevMonitors, curMonitors []int64
+	if previous.Agents != nil {
+		for _, a := range *previous.Agents {
+			prevAgents = append(prevAgents, int64Value(a.AgentID))
+		}
+	}
+	if current.Agents != nil {
+		for _, a := range *current.Agents {
+			curAgents = append(curAgents, int64Value(a.AgentID))
+		}
+	}
+	if previous.Monitors != nil {
+		for _, m := range *previous.Monitors {
+			prevMonitors = append(prevMonitors, int64Value(m.MonitorID))
+		}
+	}
+	if current.Monitors != nil {
+		for _, m := range *current.Monitors {
+			curMonitors = append(curMonitors, int64Value(m.MonitorID))
+		}
+	}
+	return !sameIDs(prevAgents, curAgents) || !sameIDs(prevMonitors, curMonitors)
+}
+
+// sameIDs reports whether a and b hold the same IDs in any order
+func sameIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[int64]int{}
+	for _, id := range a {
+		counts[id]++
+	}
+	for _, id := range b {
+		counts[id]--
+		if counts[id] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,154 @@
+package thousandeyes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// alertsServer serves the given active alerts
+type alertsServer struct {
+	mu     sync.Mutex
+	alerts string
+}
+
+func (s *alertsServer) set(alerts string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+}
+
+func (s *alertsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = w.Write([]byte(`{"alert": [` + s.alerts + `]}`))
+}
+
+func collectEvents(events *[]AlertEvent) func(AlertEvent) error {
+	return func(e AlertEvent) error {
+		*events = append(*events, e)
+		return nil
+	}
+}
+
+func TestAlertWatcher_Poll(t *testing.T) {
+	setup()
+	defer teardown()
+	alerts := &alertsServer{}
+	mux.Handle("/alerts.json", alerts)
+	mux.HandleFunc("/alerts/2.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"alert": [{"alertId": 2, "active": 0, "dateEnd": "2022-03-01 11:00:00"}]}`))
+	})
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo", Logger: NopLogger{}}
+	watcher := &AlertWatcher{Client: client}
+
+	var events []AlertEvent
+	alerts.set(`{"alertId": 2, "active": 1, "violationCount": 1, "agents": [{"agentId": 10}]},
+		{"alertId": 1, "active": 1, "violationCount": 1}`)
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 2)
+	assert.IsType(t, AlertRaised{}, events[0])
+	assert.Equal(t, Int64(1), events[0].EventAlert().AlertID)
+	assert.Equal(t, Int64(2), events[1].EventAlert().AlertID)
+
+	events = nil
+	alerts.set(`{"alertId": 2, "active": 1, "violationCount": 1, "agents": [{"agentId": 10}]},
+		{"alertId": 1, "active": 1, "violationCount": 1}`)
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 0)
+
+	events = nil
+	alerts.set(`{"alertId": 1, "active": 1, "violationCount": 2},
+		{"alertId": 3, "active": 1},
+		{"alertId": 4, "active": 0}`)
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 3)
+	updated := events[0].(AlertUpdated)
+	assert.Equal(t, Int(1), updated.Previous.ViolationCount)
+	assert.Equal(t, Int(2), updated.Alert.ViolationCount)
+	assert.Equal(t, AlertRaised{Alert: Alert{AlertID: Int64(3), Active: Int(1)}}, events[1])
+	assert.Equal(t, AlertCleared{Alert: Alert{AlertID: Int64(2), Active: Int(0), DateEnd: String("2022-03-01 11:00:00")}}, events[2])
+
+	// Alerts which cannot be fetched once cleared keep their last state
+	events = nil
+	alerts.set(`{"alertId": 3, "active": 1}`)
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Equal(t, []AlertEvent{AlertCleared{Alert: Alert{AlertID: Int64(1), Active: Int(0), ViolationCount: Int(2)}}}, events)
+}
+
+func TestAlertWatcher_Store(t *testing.T) {
+	setup()
+	defer teardown()
+	alerts := &alertsServer{}
+	mux.Handle("/alerts.json", alerts)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	store := FileAlertStore{Path: filepath.Join(t.TempDir(), "alerts.json")}
+
+	var events []AlertEvent
+	alerts.set(`{"alertId": 1, "active": 1, "agents": [{"agentId": 10}]}`)
+	watcher := &AlertWatcher{Client: client, Store: store}
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 1)
+	stored, err := store.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, Alerts{{AlertID: Int64(1), Active: Int(1), Agents: &[]Agent{{AgentID: Int64(10)}}}}, stored)
+
+	// A new watcher only reports changes since the stored state
+	events = nil
+	alerts.set(`{"alertId": 1, "active": 1, "agents": [{"agentId": 10}, {"agentId": 20}]}`)
+	watcher = &AlertWatcher{Client: client, Store: store}
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 1)
+	assert.IsType(t, AlertUpdated{}, events[0])
+}
+
+func TestAlertWatcher_HandleError(t *testing.T) {
+	setup()
+	defer teardown()
+	alerts := &alertsServer{}
+	mux.Handle("/alerts.json", alerts)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	watcher := &AlertWatcher{Client: client}
+
+	alerts.set(`{"alertId": 1, "active": 1}`)
+	failure := errors.New("failure")
+	err := watcher.Poll(context.Background(), func(AlertEvent) error { return failure })
+	assert.Equal(t, failure, err)
+
+	var events []AlertEvent
+	assert.Nil(t, watcher.Poll(context.Background(), collectEvents(&events)))
+	assert.Len(t, events, 1)
+}
+
+func TestAlertWatcher_Watch(t *testing.T) {
+	setup()
+	defer teardown()
+	alerts := &alertsServer{}
+	alerts.set(`{"alertId": 1, "active": 1}`)
+	mux.Handle("/alerts.json", alerts)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	watcher := &AlertWatcher{Client: client, Interval: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errc := watcher.Watch(ctx)
+	assert.Equal(t, AlertRaised{Alert: Alert{AlertID: Int64(1), Active: Int(1)}}, <-events)
+	cancel()
+	for range events {
+		t.Error("unchanged alerts must not be reported again")
+	}
+	assert.Equal(t, context.Canceled, <-errc)
+}
+
+func Test_alertChanged(t *testing.T) {
+	a := Alert{ViolationCount: Int(1), Agents: &[]Agent{{AgentID: Int64(1)}, {AgentID: Int64(2)}}}
+	assert.False(t, alertChanged(a, Alert{ViolationCount: Int(1), Agents: &[]Agent{{AgentID: Int64(2)}, {AgentID: Int64(1)}}}))
+	assert.True(t, alertChanged(a, Alert{ViolationCount: Int(2), Agents: a.Agents}))
+	assert.True(t, alertChanged(a, Alert{ViolationCount: Int(1), Agents: &[]Agent{{AgentID: Int64(1)}}}))
+	assert.True(t, alertChanged(Alert{}, Alert{Monitors: &[]Monitor{{MonitorID: Int64(5)}}}))
+}
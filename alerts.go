@@ -1,9 +1,11 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"net/http"
+	"time"
 )
 
 // Alerts - list of alerts
@@ -116,12 +118,17 @@ func (t *AlertRule) UnmarshalJSON(data []byte) error {
 
 // CreateAlertRule - Create alert rule
 func (c Client) CreateAlertRule(a AlertRule) (*AlertRule, error) {
-	resp, err := c.post("/alert-rules/new", a, nil)
+	return c.CreateAlertRuleWithContext(context.Background(), a)
+}
+
+// CreateAlertRuleWithContext - same as CreateAlertRule, using ctx for cancellation and deadlines
+func (c Client) CreateAlertRuleWithContext(ctx context.Context, a AlertRule) (*AlertRule, error) {
+	resp, err := c.post(ctx, "/alert-rules/new", a, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to create alert rule, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to create alert rule")
 	}
 	var target AlertRule
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -139,12 +146,17 @@ func (c Client) CreateAlertRule(a AlertRule) (*AlertRule, error) {
 
 // GetAlertRules - Get alert rules
 func (c Client) GetAlertRules() (*AlertRules, error) {
-	resp, err := c.get(fmt.Sprintf("/alert-rules"))
+	return c.GetAlertRulesWithContext(context.Background())
+}
+
+// GetAlertRulesWithContext - same as GetAlertRules, using ctx for cancellation and deadlines
+func (c Client) GetAlertRulesWithContext(ctx context.Context) (*AlertRules, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/alert-rules"))
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to get alert rule, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to get alert rule")
 	}
 
 	var target map[string]AlertRules
@@ -159,8 +171,13 @@ func (c Client) GetAlertRules() (*AlertRules, error) {
 
 // GetAlertRule - Get single alert rule by ID
 func (c *Client) GetAlertRule(id int64) (*AlertRule, error) {
-	log.Printf("[INFO] Getting Alert Rule %v", id)
-	resp, err := c.get(fmt.Sprintf("/alert-rules/%d", id))
+	return c.GetAlertRuleWithContext(context.Background(), id)
+}
+
+// GetAlertRuleWithContext - same as GetAlertRule, using ctx for cancellation and deadlines
+func (c *Client) GetAlertRuleWithContext(ctx context.Context, id int64) (*AlertRule, error) {
+	c.logger().Debug("Getting alert rule", "id", id)
+	resp, err := c.get(ctx, fmt.Sprintf("/alert-rules/%d", id))
 	if err != nil {
 		return &AlertRule{}, err
 	}
@@ -176,24 +193,34 @@ func (c *Client) GetAlertRule(id int64) (*AlertRule, error) {
 
 // DeleteAlertRule - delete alert rule
 func (c Client) DeleteAlertRule(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/alert-rules/%d/delete", id), nil, nil)
+	return c.DeleteAlertRuleWithContext(context.Background(), id)
+}
+
+// DeleteAlertRuleWithContext - same as DeleteAlertRule, using ctx for cancellation and deadlines
+func (c Client) DeleteAlertRuleWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/alert-rules/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete alert rule, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete alert rule")
 	}
 	return nil
 }
 
 // UpdateAlertRule - update alert rule
 func (c Client) UpdateAlertRule(id int64, a AlertRule) (*AlertRule, error) {
-	resp, err := c.post(fmt.Sprintf("/alert-rules/%d/update", id), a, nil)
+	return c.UpdateAlertRuleWithContext(context.Background(), id, a)
+}
+
+// UpdateAlertRuleWithContext - same as UpdateAlertRule, using ctx for cancellation and deadlines
+func (c Client) UpdateAlertRuleWithContext(ctx context.Context, id int64, a AlertRule) (*AlertRule, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/alert-rules/%d/update", id), a, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to update alert rule, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update alert rule")
 	}
 	var target AlertRule
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -201,3 +228,80 @@ func (c Client) UpdateAlertRule(id int64, a AlertRule) (*AlertRule, error) {
 	}
 	return &target, nil
 }
+
+// AlertsOptions - selects the alerts returned by GetAlerts.  Without a
+// time range, the API returns the alerts active now.
+type AlertsOptions struct {
+	// Window is a time window ending now, such as "12h" or "2d"
+	Window string
+	// From and To select an absolute time range, To defaulting to now
+	From time.Time
+	To   time.Time
+	// Active, when set, keeps only active alerts if true, or only cleared
+	// ones if false.  Cleared alerts require a Window or From.
+	Active *bool
+	// MaxPages limits the number of pages fetched, all when 0
+	MaxPages int
+}
+
+// IsActive - reports whether the alert is active
+func (a Alert) IsActive() bool {
+	return a.Active != nil && *a.Active == 1
+}
+
+// GetAlerts - Get alerts
+func (c *Client) GetAlerts(opts *AlertsOptions) (*Alerts, error) {
+	return c.GetAlertsWithContext(context.Background(), opts)
+}
+
+// GetAlertsWithContext - same as GetAlerts, using ctx for cancellation and deadlines
+func (c *Client) GetAlertsWithContext(ctx context.Context, opts *AlertsOptions) (*Alerts, error) {
+	var pageOpts *ResultsOptions
+	if opts != nil {
+		if opts.Active != nil && !*opts.Active && opts.Window == "" && opts.From.IsZero() {
+			return nil, fmt.Errorf("cleared alerts require a Window or From")
+		}
+		pageOpts = &ResultsOptions{Window: opts.Window, From: opts.From, To: opts.To, MaxPages: opts.MaxPages}
+	}
+	alerts := Alerts{}
+	err := c.getResults(ctx, "/alerts", pageOpts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Alert []Alert       `json:"alert"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		for _, a := range target.Alert {
+			if opts == nil || opts.Active == nil || a.IsActive() == *opts.Active {
+				alerts = append(alerts, a)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &alerts, nil
+}
+
+// GetAlert - Get alert by ID
+func (c *Client) GetAlert(id int64) (*Alert, error) {
+	return c.GetAlertWithContext(context.Background(), id)
+}
+
+// GetAlertWithContext - same as GetAlert, using ctx for cancellation and deadlines
+func (c *Client) GetAlertWithContext(ctx context.Context, id int64) (*Alert, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/alerts/%d", id))
+	if err != nil {
+		return nil, err
+	}
+	var target map[string][]Alert
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	if len(target["alert"]) < 1 {
+		return nil, fmt.Errorf("could not get alert %v", id)
+	}
+	return &target["alert"][0], nil
+}
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -69,7 +70,12 @@ func (t *BGP) AddAlertRule(id int64) {
 
 // GetBGP  - get bgp test
 func (c *Client) GetBGP(id int64) (*BGP, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetBGPWithContext(context.Background(), id)
+}
+
+// GetBGPWithContext - same as GetBGP, using ctx for cancellation and deadlines
+func (c *Client) GetBGPWithContext(ctx context.Context, id int64) (*BGP, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &BGP{}, err
 	}
@@ -82,12 +88,17 @@ func (c *Client) GetBGP(id int64) (*BGP, error) {
 
 // CreateBGP - Create bgp test
 func (c Client) CreateBGP(t BGP) (*BGP, error) {
-	resp, err := c.post("/tests/bgp/new", t, nil)
+	return c.CreateBGPWithContext(context.Background(), t)
+}
+
+// CreateBGPWithContext - same as CreateBGP, using ctx for cancellation and deadlines
+func (c Client) CreateBGPWithContext(ctx context.Context, t BGP) (*BGP, error) {
+	resp, err := c.post(ctx, "/tests/bgp/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create test")
 	}
 	var target map[string][]BGP
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -98,24 +109,34 @@ func (c Client) CreateBGP(t BGP) (*BGP, error) {
 
 // DeleteBGP - delete bgp test
 func (c *Client) DeleteBGP(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/bgp/%d/delete", id), nil, nil)
+	return c.DeleteBGPWithContext(context.Background(), id)
+}
+
+// DeleteBGPWithContext - same as DeleteBGP, using ctx for cancellation and deadlines
+func (c *Client) DeleteBGPWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/bgp/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete bgp test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete bgp test")
 	}
 	return nil
 }
 
 // UpdateBGP - - Update bgp trace test
 func (c *Client) UpdateBGP(id int64, t BGP) (*BGP, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/bgp/%d/update", id), t, nil)
+	return c.UpdateBGPWithContext(context.Background(), id, t)
+}
+
+// UpdateBGPWithContext - same as UpdateBGP, using ctx for cancellation and deadlines
+func (c *Client) UpdateBGPWithContext(ctx context.Context, id int64, t BGP) (*BGP, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/bgp/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]BGP
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -1,6 +1,9 @@
 package thousandeyes
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // BGPMonitors - list of bgp montors
 type BGPMonitors []BGPMonitor
@@ -16,7 +19,12 @@ type BGPMonitor struct {
 
 // GetBPGMonitors - Get bgp monitors
 func (c *Client) GetBPGMonitors() (*BGPMonitors, error) {
-	resp, err := c.get("/bgp-monitors")
+	return c.GetBPGMonitorsWithContext(context.Background())
+}
+
+// GetBPGMonitorsWithContext - same as GetBPGMonitors, using ctx for cancellation and deadlines
+func (c *Client) GetBPGMonitorsWithContext(ctx context.Context) (*BGPMonitors, error) {
+	resp, err := c.get(ctx, "/bgp-monitors")
 	if err != nil {
 		return &BGPMonitors{}, err
 	}
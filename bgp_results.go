@@ -0,0 +1,204 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// BGPMetrics - BGP metrics of a test
+type BGPMetrics struct {
+	Test    GenericTest
+	Metrics []BGPMetric
+}
+
+// BGPMetric - reachability and route changes of a prefix seen by a BGP
+// monitor in a round.  Reachability is a percentage.
+type BGPMetric struct {
+	MonitorID       *int64   `json:"monitorId,omitempty"`
+	MonitorName     *string  `json:"monitorName,omitempty"`
+	CountryID       *string  `json:"countryId,omitempty"`
+	Date            *string  `json:"date,omitempty"`
+	RoundID         *int64   `json:"roundId,omitempty"`
+	Prefix          *string  `json:"prefix,omitempty"`
+	PrefixID        *int64   `json:"prefixId,omitempty"`
+	Reachability    *float64 `json:"reachability,omitempty"`
+	NumberOfUpdates *int     `json:"numberOfUpdates,omitempty"`
+	PathChanges     *int     `json:"pathChanges,omitempty"`
+	Permalink       *string  `json:"permalink,omitempty"`
+}
+
+// BGPRoutes - routes to a prefix seen by the BGP monitors in a round
+type BGPRoutes struct {
+	Test   GenericTest
+	Routes []BGPRoute
+}
+
+// BGPRoute - the route to a prefix seen by a BGP monitor.  ASPath starts
+// with the monitor's neighbor and ends with the origin AS.
+type BGPRoute struct {
+	MonitorID   *int64   `json:"monitorId,omitempty"`
+	MonitorName *string  `json:"monitorName,omitempty"`
+	Date        *string  `json:"date,omitempty"`
+	RoundID     *int64   `json:"roundId,omitempty"`
+	Prefix      *string  `json:"prefix,omitempty"`
+	ASPath      *[]int64 `json:"asPath,omitempty"`
+	Reachable   *bool    `json:"reachable,omitempty"`
+}
+
+// BGPRouteChange - how the route of a monitor to a prefix differs between
+// two rounds.  Before is nil for added routes, After for removed ones.
+type BGPRouteChange struct {
+	MonitorID     int64
+	Prefix        string
+	Before        *BGPRoute
+	After         *BGPRoute
+	OriginChanged bool
+}
+
+// Monitor - returns the BGP monitor which reported the metrics
+func (m BGPMetric) Monitor() BGPMonitor {
+	return BGPMonitor{MonitorID: m.MonitorID, MonitorName: m.MonitorName}
+}
+
+// OriginAS - returns the origin AS of the route, 0 when the AS path is
+// empty
+func (r BGPRoute) OriginAS() int64 {
+	if r.ASPath == nil || len(*r.ASPath) == 0 {
+		return 0
+	}
+	return (*r.ASPath)[len(*r.ASPath)-1]
+}
+
+// DiffBGPRoutes - compares the routes of two rounds, returning the routes
+// whose AS path changed, appeared or disappeared, ordered by prefix and
+// monitor.  A changed origin AS, as caused by hijacks, is flagged with
+// OriginChanged.
+func DiffBGPRoutes(before, after []BGPRoute) []BGPRouteChange {
+	type key struct {
+		monitor int64
+		prefix  string
+	}
+	changes := map[key]*BGPRouteChange{}
+	keys := []key{}
+	change := func(r BGPRoute) *BGPRouteChange {
+		k := key{int64Value(r.MonitorID), stringValue(r.Prefix)}
+		if c, ok := changes[k]; ok {
+			return c
+		}
+		c := &BGPRouteChange{MonitorID: k.monitor, Prefix: k.prefix}
+		changes[k] = c
+		keys = append(keys, k)
+		return c
+	}
+	for i := range before {
+		change(before[i]).Before = &before[i]
+	}
+	for i := range after {
+		change(after[i]).After = &after[i]
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].prefix != keys[j].prefix {
+			return keys[i].prefix < keys[j].prefix
+		}
+		return keys[i].monitor < keys[j].monitor
+	})
+	diff := []BGPRouteChange{}
+	for _, k := range keys {
+		c := changes[k]
+		if c.Before != nil && c.After != nil {
+			if sameASPath(c.Before.ASPath, c.After.ASPath) {
+				continue
+			}
+			c.OriginChanged = c.Before.OriginAS() != c.After.OriginAS()
+		}
+		diff = append(diff, *c)
+	}
+	return diff
+}
+
+func sameASPath(a, b *[]int64) bool {
+	var x, y []int64
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// GetBGPMetrics - Get BGP metrics of a test.  The AgentIDs of opts do not
+// apply to BGP monitors.
+func (c *Client) GetBGPMetrics(testID int64, opts *ResultsOptions) (*BGPMetrics, error) {
+	return c.GetBGPMetricsWithContext(context.Background(), testID, opts)
+}
+
+// GetBGPMetricsWithContext - same as GetBGPMetrics, using ctx for cancellation and deadlines
+func (c *Client) GetBGPMetricsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*BGPMetrics, error) {
+	results := BGPMetrics{Metrics: []BGPMetric{}}
+	err := c.getResults(ctx, fmt.Sprintf("/net/bgp-metrics/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Net struct {
+				Test       GenericTest `json:"test"`
+				BGPMetrics []BGPMetric `json:"bgpMetrics"`
+			} `json:"net"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Net.Test
+		for _, m := range target.Net.BGPMetrics {
+			if opts.includes(nil, m.RoundID) {
+				results.Metrics = append(results.Metrics, m)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// GetBGPRoutes - Get the routes to a prefix of a test in a round.  The
+// prefixID is reported by GetBGPMetrics.
+func (c *Client) GetBGPRoutes(testID, prefixID, roundID int64) (*BGPRoutes, error) {
+	return c.GetBGPRoutesWithContext(context.Background(), testID, prefixID, roundID)
+}
+
+// GetBGPRoutesWithContext - same as GetBGPRoutes, using ctx for cancellation and deadlines
+func (c *Client) GetBGPRoutesWithContext(ctx context.Context, testID, prefixID, roundID int64) (*BGPRoutes, error) {
+	results := BGPRoutes{Routes: []BGPRoute{}}
+	path := fmt.Sprintf("/net/bgp-routes/%d/%d/%d", testID, prefixID, roundID)
+	err := c.getResults(ctx, path, nil, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Net struct {
+				Test      GenericTest `json:"test"`
+				BGPRoutes []BGPRoute  `json:"bgpRoutes"`
+			} `json:"net"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Net.Test
+		results.Routes = append(results.Routes, target.Net.BGPRoutes...)
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
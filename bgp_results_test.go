@@ -0,0 +1,89 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetBGPMetrics(t *testing.T) {
+	out := `{"net": {"test": {"testId": 1, "type": "bgp"}, "bgpMetrics": [
+		{"monitorId": 5, "monitorName": "Seattle-3", "countryId": "US", "roundId": 100, "prefix": "192.0.2.0/24",
+		 "prefixId": 42, "reachability": 100, "numberOfUpdates": 2, "pathChanges": 1},
+		{"monitorId": 6, "monitorName": "London-1", "roundId": 200, "prefix": "192.0.2.0/24", "prefixId": 42,
+		 "reachability": 0}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/bgp-metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetBGPMetrics(1, &ResultsOptions{RoundID: 100, AgentIDs: []int64{1}})
+	assert.Nil(t, err)
+	assert.Equal(t, []BGPMetric{{
+		MonitorID:       Int64(5),
+		MonitorName:     String("Seattle-3"),
+		CountryID:       String("US"),
+		RoundID:         Int64(100),
+		Prefix:          String("192.0.2.0/24"),
+		PrefixID:        Int64(42),
+		Reachability:    Float64(100),
+		NumberOfUpdates: Int(2),
+		PathChanges:     Int(1),
+	}}, res.Metrics)
+	assert.Equal(t, BGPMonitor{MonitorID: Int64(5), MonitorName: String("Seattle-3")}, res.Metrics[0].Monitor())
+}
+
+func TestClient_GetBGPRoutes(t *testing.T) {
+	out := `{"net": {"test": {"testId": 1}, "bgpRoutes": [
+		{"monitorId": 5, "monitorName": "Seattle-3", "roundId": 100, "prefix": "192.0.2.0/24",
+		 "asPath": [3356, 1299, 64500], "reachable": true}
+	]}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/bgp-routes/1/42/100.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetBGPRoutes(1, 42, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, &[]int64{3356, 1299, 64500}, res.Routes[0].ASPath)
+	assert.Equal(t, int64(64500), res.Routes[0].OriginAS())
+}
+
+func TestDiffBGPRoutes(t *testing.T) {
+	prefix := String("192.0.2.0/24")
+	before := []BGPRoute{
+		{MonitorID: Int64(1), Prefix: prefix, ASPath: &[]int64{3356, 64500}},
+		{MonitorID: Int64(2), Prefix: prefix, ASPath: &[]int64{1299, 64500}},
+		{MonitorID: Int64(3), Prefix: prefix, ASPath: &[]int64{174, 64500}},
+		{MonitorID: Int64(4), Prefix: prefix, ASPath: &[]int64{2914, 64500}},
+	}
+	after := []BGPRoute{
+		{MonitorID: Int64(1), Prefix: prefix, ASPath: &[]int64{3356, 64500}},
+		{MonitorID: Int64(2), Prefix: prefix, ASPath: &[]int64{1299, 6939, 64500}},
+		{MonitorID: Int64(3), Prefix: prefix, ASPath: &[]int64{174, 64666}},
+		{MonitorID: Int64(5), Prefix: prefix, ASPath: &[]int64{6453, 64500}},
+	}
+
+	diff := DiffBGPRoutes(before, after)
+	assert.Len(t, diff, 4)
+	assert.Equal(t, int64(2), diff[0].MonitorID)
+	assert.False(t, diff[0].OriginChanged)
+	assert.Equal(t, &[]int64{1299, 6939, 64500}, diff[0].After.ASPath)
+	assert.Equal(t, int64(3), diff[1].MonitorID)
+	assert.True(t, diff[1].OriginChanged)
+	assert.Equal(t, int64(4), diff[2].MonitorID)
+	assert.Nil(t, diff[2].After)
+	assert.Equal(t, int64(5), diff[3].MonitorID)
+	assert.Nil(t, diff[3].Before)
+
+	assert.Equal(t, []BGPRouteChange{}, DiffBGPRoutes(before, before))
+	assert.Equal(t, int64(0), BGPRoute{}.OriginAS())
+}
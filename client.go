@@ -2,33 +2,25 @@ package thousandeyes
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	defaultAPIEndpoint = "https://api.thousandeyes.com/v6"
+	defaultAPIHost     = "https://api.thousandeyes.com/"
 )
 
-var orgRate RateLimit
-var instantTestRate RateLimit
-
-// RateLimit contains data representing rate limit headers returned in
-// ThousandEyes API responses.  int64 everywhere for ease of interacting
-// with time values.
-type RateLimit struct {
-	Limit              int64
-	Remaining          int64
-	Reset              int64
-	LastRemaining      int64
-	ConcurrentMessages []time.Time
-}
+// API versions supported by ClientOptions.APIVersion
+const (
+	APIVersion6 = "v6"
+	APIVersion7 = "v7"
+)
 
 // APILinks - List of APILink
 type APILinks []APILink
@@ -48,7 +40,15 @@ type Limiter interface {
 	Wait()
 }
 
-// HTTPClient - an http client
+// ContextLimiter - Rate limiter whose wait can be abandoned when a context
+// is cancelled. Limiters which only implement Limiter are still honoured,
+// but the call returns as soon as the context is done.
+type ContextLimiter interface {
+	Limiter
+	WaitWithContext(ctx context.Context) error
+}
+
+// HTTPClient - an http client, satisfied by *http.Client
 type HTTPClient interface {
 	Do(*http.Request) (*http.Response, error)
 }
@@ -57,12 +57,42 @@ type HTTPClient interface {
 // rate limiter, and HTTP client settings
 type ClientOptions struct {
 	APIEndpoint string
-	Limiter     Limiter
-	AccountID   string
-	AuthToken   string
-	Timeout     time.Duration
+	// APIVersion selects the API the client talks to, APIVersion6 by
+	// default.  It determines the default APIEndpoint and whether paths
+	// take the ".json" suffix required by v6.
+	APIVersion string
+	Limiter    Limiter
+	AccountID  string
+	AuthToken  string
+	// Timeout applies to the default HTTP client only, and is ignored
+	// when HTTPClient is set.
+	Timeout time.Duration
+	// HTTPClient replaces the HTTP client used for every call, e.g. to
+	// record requests or configure proxies and TLS.
+	HTTPClient HTTPClient
+	// Transport is used by the default HTTP client when HTTPClient is
+	// unset.  http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
 	// http client user-agent
 	UserAgent string
+	// RateLimits may be shared between clients of the same organization
+	// so that they pace against a common budget. A new state is created
+	// for the client when unset.
+	RateLimits *RateLimitState
+	// RetryPolicy controls retries of failed calls.  DefaultRetryPolicy
+	// is used when unset.
+	RetryPolicy *RetryPolicy
+	// Middleware wraps every API call, the first entry being outermost.
+	// The default authorization, user-agent and account group handling
+	// runs before any of them.
+	Middleware []Middleware
+	// Logger receives diagnostic messages such as rate limit sleeps and
+	// retries.  A StdLogger is used when unset; use NopLogger to silence
+	// the SDK.
+	Logger Logger
+	// LogBodies logs request and response bodies at debug level, with
+	// credentials redacted.
+	LogBodies bool
 }
 
 // Client wraps http client
@@ -70,9 +100,26 @@ type Client struct {
 	AuthToken      string
 	AccountGroupID string
 	APIEndpoint    string
-	HTTPClient     http.Client
-	Limiter        Limiter
-	UserAgent      string
+	// APIVersion is the API version of APIEndpoint, v6 when empty
+	APIVersion string
+	// HTTPClient sends every request.  A client without a timeout is
+	// used when nil.
+	HTTPClient HTTPClient
+	Limiter    Limiter
+	UserAgent  string
+	// RateLimits tracks the rate limit headers returned by the API.  A
+	// package level state, shared by every such client, is used when nil.
+	RateLimits *RateLimitState
+	// RetryPolicy controls retries of failed calls.  DefaultRetryPolicy
+	// is used when nil.
+	RetryPolicy *RetryPolicy
+	// Middleware wraps every API call, the first entry being outermost.
+	Middleware []Middleware
+	// Logger receives diagnostic messages.  A StdLogger is used when nil.
+	Logger Logger
+	// LogBodies logs request and response bodies at debug level, with
+	// credentials redacted.
+	LogBodies bool
 }
 
 // DefaultLimiter -  thousandeyes rate limit is 240 per minute
@@ -83,10 +130,23 @@ func (l DefaultLimiter) Wait() {
 	time.Sleep(time.Millisecond * 300)
 }
 
+// WaitWithContext - Satisfying the ContextLimiter interface, returning early
+// with the context error if ctx is done before the 300ms have elapsed
+func (l DefaultLimiter) WaitWithContext(ctx context.Context) error {
+	return sleep(ctx, time.Millisecond*300)
+}
+
 // NewClient creates an API client
 func NewClient(opts *ClientOptions) *Client {
-	if opts.APIEndpoint == "" {
-		opts.APIEndpoint = defaultAPIEndpoint
+	// Defaults are not stored in opts, so that the same options can be
+	// used for clients of other API versions or with other state.
+	apiVersion := opts.APIVersion
+	if apiVersion == "" {
+		apiVersion = APIVersion6
+	}
+	apiEndpoint := opts.APIEndpoint
+	if apiEndpoint == "" {
+		apiEndpoint = defaultAPIHost + apiVersion
 	}
 
 	// Set default timeout if a custom duration is 0 or unset (since we
@@ -101,93 +161,208 @@ func NewClient(opts *ClientOptions) *Client {
 		timeout = time.Second * 20
 	}
 
-	if opts.UserAgent == "" {
-		opts.UserAgent = "ThousandEyes Go SDK"
+	// Clients created from the same options do not share an HTTP client
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout:   timeout,
+			Transport: opts.Transport,
+		}
+	}
+
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = "ThousandEyes Go SDK"
+	}
+
+	rateLimits := opts.RateLimits
+	if rateLimits == nil {
+		rateLimits = NewRateLimitState()
 	}
 
 	return &Client{
 		AuthToken:      opts.AuthToken,
 		AccountGroupID: opts.AccountID,
-		APIEndpoint:    opts.APIEndpoint,
-		HTTPClient: http.Client{
-			Timeout: timeout,
-		},
-		Limiter:   opts.Limiter,
-		UserAgent: opts.UserAgent,
+		APIEndpoint:    apiEndpoint,
+		APIVersion:     apiVersion,
+		HTTPClient:     httpClient,
+		Limiter:        opts.Limiter,
+		UserAgent:      userAgent,
+		RateLimits:     rateLimits,
+		RetryPolicy:    opts.RetryPolicy,
+		Middleware:     opts.Middleware,
+		Logger:         opts.Logger,
+		LogBodies:      opts.LogBodies,
 	}
 }
 
-func (c *Client) delete(path string) (*http.Response, error) {
-	return c.do("DELETE", path, nil, nil)
+// Request performs a call to path, relative to the APIEndpoint, sending
+// payload as the JSON body when it is not nil.  It allows reaching
+// endpoints the SDK has no method for, and is the transport of the v7
+// package.  The caller must close the response body.
+func (c *Client) Request(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	if payload == nil {
+		return c.do(ctx, method, path, nil, nil)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, method, path, data, nil)
 }
 
-func (c *Client) put(path string, payload interface{}, headers *map[string]string) (*http.Response, error) {
+// formatPath adds the ".json" format suffix v6 requires to path, before
+// any query string
+func (c *Client) formatPath(path string) string {
+	if c.APIVersion != "" && c.APIVersion != APIVersion6 {
+		return path
+	}
+	if i := strings.Index(path, "?"); i >= 0 {
+		return path[:i] + ".json" + path[i:]
+	}
+	return path + ".json"
+}
+
+func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (c *Client) put(ctx context.Context, path string, payload interface{}, headers *map[string]string) (*http.Response, error) {
 	if payload != nil {
 		data, err := json.Marshal(payload)
 		if err != nil {
 			return nil, err
 		}
-		return c.do("PUT", path, bytes.NewBuffer(data), headers)
+		return c.do(ctx, "PUT", path, data, headers)
 	}
-	return c.do("PUT", path, nil, headers)
+	return c.do(ctx, "PUT", path, nil, headers)
 }
 
-func (c *Client) post(path string, payload interface{}, headers *map[string]string) (*http.Response, error) {
+func (c *Client) post(ctx context.Context, path string, payload interface{}, headers *map[string]string) (*http.Response, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	return c.do("POST", path, bytes.NewBuffer(data), headers)
+	return c.do(ctx, "POST", path, data, headers)
 }
 
-func (c *Client) get(path string) (*http.Response, error) {
-	return c.do("GET", path, nil, nil)
+func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, "GET", path, nil, nil)
 }
 
-func (c *Client) do(method, path string, body io.Reader, headers *map[string]string) (*http.Response, error) {
-	if c.Limiter != nil {
-		c.Limiter.Wait()
-	}
-	endpoint := c.APIEndpoint + path + ".json"
-	req, _ := http.NewRequest(method, endpoint, body)
-	if c.AccountGroupID != "" {
-		q := req.URL.Query()
-		q.Add("aid", c.AccountGroupID)
-		req.URL.RawQuery = q.Encode()
-	}
-	req.Header.Set("accept", "application/json")
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
-	req.Header.Set("content-type", "application/json")
-	req.Header.Set("user-agent", c.UserAgent)
+func (c *Client) do(ctx context.Context, method, path string, body []byte, headers *map[string]string) (*http.Response, error) {
+	apiPath := strings.SplitN(path, "?", 2)[0]
+	stats := &CallStats{Path: apiPath, PathTemplate: PathTemplate(apiPath)}
+	ctx = context.WithValue(ctx, callStatsKey{}, stats)
+	endpoint := c.APIEndpoint + c.formatPath(path)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	send := c.send
+	if c.LogBodies {
+		send = c.bodyLoggingMiddleware(send)
+	}
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		send = c.Middleware[i](send)
+	}
 	if headers != nil {
-		for k, v := range *headers {
-			req.Header.Set(k, v)
+		send = headersMiddleware(*headers)(send)
+	}
+	send = c.defaultHeadersMiddleware(c.accountGroupMiddleware(send))
+	return send(req)
+}
+
+// send waits on the limiter and any rate limit pacing, then performs req,
+// retrying as permitted by the client's RetryPolicy.  It is the innermost
+// Handler of the middleware chain.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	stats := CallStatsFromContext(ctx)
+	if stats == nil {
+		stats = &CallStats{}
+	}
+	if c.Limiter != nil {
+		if err := wait(ctx, c.Limiter); err != nil {
+			return nil, err
 		}
 	}
 
 	// Perform any delays required by previously observed rate headers
-	delay := setDelay(req, nil, time.Now())
-	time.Sleep(delay)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
+	delay := c.rateLimits().setDelay(req, nil, time.Now())
+	if delay > 0 {
+		rate := c.rateLimits().forRequest(req)
+		c.logger().Info("Sleeping to prevent rate limiting",
+			"remaining", rate.Remaining, "limit", rate.Limit, "delay", delay)
+		stats.RateLimitDelay += delay
+	}
+	if err := sleep(ctx, delay); err != nil {
 		return nil, err
 	}
 
-	// Store reported rate limit status
-	storeLimits(req, resp, time.Now())
+	policy := c.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		// The body is consumed by each attempt, so it is rebuilt every time
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		resp, err = httpClient.Do(req)
+		stats.Attempts++
+		if err == nil {
+			// Store reported rate limit status
+			c.rateLimits().storeLimits(req, resp, time.Now())
+			if resp.StatusCode == http.StatusTooManyRequests {
+				stats.RateLimited++
+			}
+		}
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(ctx, resp, err) {
+			break
+		}
 
-	// If request was rate limited, back off and retry.
-	// We shouldn't typically need to do this, because the above delays should
-	// prevent us from hitting the limit, but there may be other users in an
-	// org who might have triggered the limiting.
-	if resp.StatusCode == 429 {
-		delay := setDelay(req, resp, time.Now())
-		time.Sleep(delay)
-		resp, err = c.HTTPClient.Do(req)
+		// Back off and retry.  For rate limited requests we shouldn't
+		// typically need to do this, because the above delays should
+		// prevent us from hitting the limit, but there may be other users
+		// in an org who might have triggered the limiting.
+		delay := c.retryDelay(policy, attempt, req, resp)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			stats.RateLimitDelay += delay
+		} else {
+			stats.RetryDelay += delay
+		}
+		if resp != nil {
+			c.logger().Info("Retrying request", "method", req.Method, "path", req.URL.Path,
+				"status", resp.StatusCode, "attempt", attempt, "delay", delay)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			c.logger().Info("Retrying request", "method", req.Method, "path", req.URL.Path,
+				"error", err, "attempt", attempt, "delay", delay)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return c.checkResponse(resp, err)
+	return c.checkResponse(resp, nil)
 }
 
 func (c *Client) decodeJSON(resp *http.Response, payload interface{}) error {
@@ -203,10 +378,20 @@ func (c *Client) checkResponse(resp *http.Response, err error) (*http.Response,
 	if 199 >= resp.StatusCode || 300 <= resp.StatusCode {
 		var eo *errorObject
 		var getErr error
-		if eo, getErr = c.getErrorFromResponse(resp); getErr != nil || eo.ErrorMessage == nil {
-			return resp, fmt.Errorf("Response did not contain formatted error: %s. HTTP response code: %v. Raw response: %+v", getErr, resp.StatusCode, resp)
+		apiErr := NewAPIError(resp)
+		// The body is kept readable for middleware, such as body logging
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		eo, getErr = c.getErrorFromResponse(resp)
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if getErr != nil || eo.ErrorMessage == nil {
+			apiErr.message = fmt.Sprintf("Response did not contain formatted error: %s. HTTP response code: %v. Raw response: %+v", getErr, resp.StatusCode, resp)
+			return resp, apiErr
 		}
-		return resp, fmt.Errorf("Failed call API endpoint. HTTP response code: %v. Error: %s", resp.StatusCode, *eo.ErrorMessage)
+		apiErr.ErrorMessage = *eo.ErrorMessage
+		apiErr.message = fmt.Sprintf("Failed call API endpoint. HTTP response code: %v. Error: %s", resp.StatusCode, *eo.ErrorMessage)
+		return resp, apiErr
 	}
 	return resp, nil
 }
@@ -219,101 +404,36 @@ func (c *Client) getErrorFromResponse(resp *http.Response) (*errorObject, error)
 	return &result, nil
 }
 
-// setDelay determines the pause time needed to prevent invoking rate limiting
-func setDelay(req *http.Request, resp *http.Response, now time.Time) time.Duration {
-	// Choose which rate limit applies
-	var delay time.Duration
-	var rate RateLimit
-	if resp == nil {
-		resp = &http.Response{}
-	}
-	instantTest := isInstantTest(req)
-	if instantTest {
-		rate = instantTestRate
-	} else {
-		rate = orgRate
-	}
-
-	// If the limit is 0, this is either our first request or we are not receiving
-	// rate limit data in the headers
-	if rate.Limit == 0 {
-		return 0
-	}
-
-	// If this is the first time we've sent this particular request and we
-	// aren't at the end of our remaining requests for the period...
-	if rate.Remaining > 1 && resp.StatusCode != 429 {
-		baseDelay := 1.0 / float64(rate.Limit) * float64(time.Minute.Nanoseconds())
-		// The rate limit is per minute, so if there was a zero response time
-		// then the ideal delay would be the one minute divided by the rate.
-		// To account for potential other users, we will multiply by the
-		// difference between the remaining count and our last seen remaining
-		// count.
-		delta := rate.LastRemaining - rate.Remaining
-		if delta < 1 {
-			delta = 1
-		}
-
-		// It's possible that these calls could be made concurrently, in which
-		// case the pacing delay would effectively be divided by the batch size.
-		// To account for this, we track messages sent for this session and
-		// account for any that have delays which have not expired.
-		for i, t := range rate.ConcurrentMessages {
-			if t.Sub(now) >= time.Duration(0) {
-				rate.ConcurrentMessages = rate.ConcurrentMessages[i:]
-				break
-			}
-		}
-
-		delta += int64(len(rate.ConcurrentMessages))
-		delay = time.Duration(baseDelay * float64(delta))
-		rate.ConcurrentMessages = append(rate.ConcurrentMessages, now.Add(delay))
-		log.Printf("[INFO] %v of %v requests / min remain.  Sleeping %v to prevent rate limiting.",
-			rate.Remaining, rate.Limit, delay)
-	} else {
-		// else calculate delay until resume time.
-		// Assume our clock is roughly in sync with the clock setting the resume time.
-		delay = time.Duration((rate.Reset - now.Unix() + 1) * time.Second.Nanoseconds())
-		// ThousandEyes rates reset within one minute (but not guaranteed).
-		// If we exceed a minute wait time, something may be wrong.
-		if delay > time.Minute {
-			delay = time.Minute
-		}
-		log.Printf("[INFO] Rate Limited: Sleeping %v before resubmitting\n", delay)
+// wait blocks on the limiter until it releases or ctx is done.
+func wait(ctx context.Context, l Limiter) error {
+	if cl, ok := l.(ContextLimiter); ok {
+		return cl.WaitWithContext(ctx)
 	}
-	if instantTest {
-		instantTestRate.ConcurrentMessages = rate.ConcurrentMessages
-	} else {
-		orgRate.ConcurrentMessages = rate.ConcurrentMessages
+	done := make(chan struct{})
+	go func() {
+		l.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	return delay
 }
 
-// storeLimits assigns the global variables to track current rate limit data
-func storeLimits(req *http.Request, resp *http.Response, now time.Time) {
-	// We discard errors, because an error or blank result also return 0
-	if resp.Header != nil {
-		if v := resp.Header.Get("X-Organization-Rate-Limit-Limit"); v != "" {
-			orgRate.Limit, _ = strconv.ParseInt(v, 10, 64)
-		}
-		if v := resp.Header.Get("X-Organization-Rate-Limit-Remaining"); v != "" {
-			orgRate.Remaining, _ = strconv.ParseInt(v, 10, 64)
-		}
-		if v := resp.Header.Get("X-Organization-Rate-Limit-Reset"); v != "" {
-			orgRate.Reset, _ = strconv.ParseInt(v, 10, 64)
-		}
-		if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Limit"); v != "" {
-			instantTestRate.Limit, _ = strconv.ParseInt(v, 10, 64)
-		}
-		if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Remaining"); v != "" {
-			instantTestRate.Remaining, _ = strconv.ParseInt(v, 10, 64)
-		}
-		if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Reset"); v != "" {
-			instantTestRate.Reset, _ = strconv.ParseInt(v, 10, 64)
-		}
+// sleep pauses for d, returning early with the context error if ctx is
+// done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-}
-
-func isInstantTest(req *http.Request) bool {
-	return strings.HasPrefix(req.URL.Path, "/v6/instant") == true || strings.HasPrefix(req.URL.Path, "/v6/endpoint-instant")
 }
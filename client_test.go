@@ -1,6 +1,9 @@
 package thousandeyes
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -107,145 +110,136 @@ func Test_ClientAPIEndpoint(t *testing.T) {
 	assert.Equal(t, overrideAPIEndpoint, client.APIEndpoint)
 }
 
-func Test_setDelay(t *testing.T) {
+type blockingLimiter struct {
+	release chan struct{}
+}
+
+func (l blockingLimiter) Wait() {
+	<-l.release
+}
+
+func Test_ClientContextCancelled(t *testing.T) {
 	setup()
-	now := time.Now()
-
-	var delay time.Duration
-	var req *http.Request
-	var resp *http.Response
-	orgRate = RateLimit{}
-	instantTestRate = RateLimit{}
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
-	resp = &http.Response{}
-	resp.Header = make(map[string][]string)
-
-	// Test initial requests, for which rate limit data is not available
-	orgRate = RateLimit{}
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, time.Duration(0), delay)
-
-	// Test subsequent requests with rate limit data
-	// Old concurrent messages should be purged.
-	orgRate = RateLimit{
-		Limit:         240,
-		Remaining:     100,
-		Reset:         now.Add(30 * time.Second).Unix(),
-		LastRemaining: 101,
-		ConcurrentMessages: []time.Time{
-			now.Add(-1000 * time.Millisecond),
-			now.Add(-750 * time.Millisecond),
-			now,
-			now.Add(250 * time.Millisecond),
-		},
-	}
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 750*time.Millisecond, delay)
-
-	// All complications from valid state:
-	orgRate = RateLimit{
-		Limit:         240,
-		Remaining:     100,
-		Reset:         now.Add(30 * time.Second).Unix(),
-		LastRemaining: 104,
-		ConcurrentMessages: []time.Time{
-			now.Add(1000 * time.Millisecond),
-			now.Add(750 * time.Millisecond),
-			now.Add(500 * time.Millisecond),
-			now.Add(250 * time.Millisecond),
-		},
-	}
-	instantTestRate = orgRate // Use state to test instant test below
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 2*time.Second, delay)
-
-	// Same result should be obtained for an instant test
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 2*time.Second, delay)
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
-
-	// LastTime over the minimum delay time should result in the minimum delay time if there
-	// are no concurrent messages and last remaining has not decreased by more than 1.
-	orgRate.LastRemaining = 101
-	orgRate.ConcurrentMessages = []time.Time{}
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, time.Duration(250*time.Millisecond), delay)
-
-	// A passed response means we should delay, as this is presently only done
-	// in response to a 429
-	resp.StatusCode = 429
-	delay = setDelay(req, resp, now)
-	assert.Equal(t, 31*time.Second, delay)
-
-	// Remaining messages being under the minimum should also result in waiting
-	// until reset
-	orgRate.Remaining = 1
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 31*time.Second, delay)
-
-	// Test conflicting or invalid states
-	// After reset, LastRemaining may be larger than Remaining
-	orgRate = RateLimit{
-		Limit:              240,
-		Remaining:          240,
-		Reset:              now.Add(30 * time.Second).Unix(),
-		LastRemaining:      2,
-		ConcurrentMessages: []time.Time{},
-	}
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 250*time.Millisecond, delay)
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent with a cancelled context")
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetAgentsWithContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
 
-	// Delays over one minute should be shortened to one minute
-	orgRate.Remaining = 0
-	orgRate.Reset = now.Add(120 * time.Second).Unix()
-	delay = setDelay(req, nil, now)
-	assert.Equal(t, 1*time.Minute, delay)
+func Test_ClientContextInterruptsLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+	l := blockingLimiter{release: make(chan struct{})}
+	defer close(l.release)
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo", Limiter: l}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetAgentsWithContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_DefaultLimiterWaitWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := DefaultLimiter{}.WaitWithContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.Less(t, int64(time.Since(start)), int64(300*time.Millisecond))
+}
+
+func Test_sleep(t *testing.T) {
+	assert.Nil(t, sleep(context.Background(), 0))
+	assert.Nil(t, sleep(context.Background(), time.Millisecond))
 
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, sleep(ctx, time.Minute))
 }
 
-func Test_storeLimits(t *testing.T) {
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func Test_ClientTransport(t *testing.T) {
 	setup()
-	now := time.Now()
-	destRate := RateLimit{
-		Limit:     240,
-		Remaining: 2,
-		Reset:     120,
-	}
+	defer teardown()
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+
+	rt := &recordingTransport{}
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, Transport: rt, Timeout: 5 * time.Second})
+	_, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Len(t, rt.requests, 1)
+	assert.Equal(t, 5*time.Second, client.HTTPClient.(*http.Client).Timeout)
+}
+
+func Test_ClientHTTPClient(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+
+	rt := &recordingTransport{}
+	httpClient := &http.Client{Transport: rt}
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, HTTPClient: httpClient, Timeout: 5 * time.Second})
+	_, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Len(t, rt.requests, 1)
+	// The timeout option does not override a supplied client
+	assert.Same(t, httpClient, client.HTTPClient)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+}
+
+func Test_ClientDefaultHTTPClient(t *testing.T) {
+	client := NewClient(&ClientOptions{})
+	assert.Equal(t, 20*time.Second, client.HTTPClient.(*http.Client).Timeout)
+
+	// Clients created from the same options do not share state
+	opts := ClientOptions{}
+	first := NewClient(&opts)
+	second := NewClient(&opts)
+	assert.NotSame(t, first.HTTPClient, second.HTTPClient)
+	assert.NotSame(t, first.RateLimits, second.RateLimits)
+}
+
+func Test_ClientAPIVersion(t *testing.T) {
+	client := NewClient(&ClientOptions{APIVersion: APIVersion7})
+	assert.Equal(t, "https://api.thousandeyes.com/v7", client.APIEndpoint)
+	assert.Equal(t, "/tests?cursor=abc", client.formatPath("/tests?cursor=abc"))
+
+	client = NewClient(&ClientOptions{})
+	assert.Equal(t, APIVersion6, client.APIVersion)
+	assert.Equal(t, "/tests.json", client.formatPath("/tests"))
+	assert.Equal(t, "/net/metrics/1.json?window=1h", client.formatPath("/net/metrics/1?window=1h"))
+}
+
+func Test_ClientRequest(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/tests/agent-to-server", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "abc", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "bar", r.URL.Query().Get("aid"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"testName":"test"}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+	})
 
-	var req *http.Request
-	var resp *http.Response
-	orgRate = RateLimit{}
-	instantTestRate = RateLimit{}
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
-	resp = &http.Response{}
-	resp.Header = make(map[string][]string)
-	resp.Header.Add("X-Organization-Rate-Limit-Limit", "240")
-	resp.Header.Add("X-Organization-Rate-Limit-Remaining", "2")
-	resp.Header.Add("X-Organization-Rate-Limit-Reset", "120")
-	storeLimits(req, resp, now)
-	assert.Equal(t, destRate, orgRate)
-	assert.Equal(t, RateLimit{}, instantTestRate)
-
-	orgRate = RateLimit{}
-	instantTestRate = RateLimit{}
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
-	resp = &http.Response{}
-	resp.Header = make(map[string][]string)
-	resp.Header.Add("X-Instant-Test-Rate-Limit-Limit", "240")
-	resp.Header.Add("X-Instant-Test-Rate-Limit-Remaining", "2")
-	resp.Header.Add("X-Instant-Test-Rate-Limit-Reset", "120")
-	storeLimits(req, resp, now)
-	assert.Equal(t, destRate, instantTestRate)
-	assert.Equal(t, RateLimit{}, orgRate)
-}
-
-func Test_isInstantTest(t *testing.T) {
-	var req *http.Request
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
-	assert.Equal(t, true, isInstantTest(req))
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/endpoint-instant/agent-to-server.json", nil)
-	assert.Equal(t, true, isInstantTest(req))
-	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
-	assert.Equal(t, false, isInstantTest(req))
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, APIVersion: APIVersion7, AccountID: "bar"})
+	resp, err := client.Request(context.Background(), "POST", "/tests/agent-to-server?cursor=abc", map[string]string{"testName": "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 }
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -74,7 +75,12 @@ func (t *DNSSec) AddAlertRule(id int64) {
 
 // GetDNSSec - get DNSSec test
 func (c *Client) GetDNSSec(id int64) (*DNSSec, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetDNSSecWithContext(context.Background(), id)
+}
+
+// GetDNSSecWithContext - same as GetDNSSec, using ctx for cancellation and deadlines
+func (c *Client) GetDNSSecWithContext(ctx context.Context, id int64) (*DNSSec, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &DNSSec{}, err
 	}
@@ -87,12 +93,17 @@ func (c *Client) GetDNSSec(id int64) (*DNSSec, error) {
 
 // CreateDNSSec - Create DNSSec test
 func (c Client) CreateDNSSec(t DNSSec) (*DNSSec, error) {
-	resp, err := c.post("/tests/dns-dnssec/new", t, nil)
+	return c.CreateDNSSecWithContext(context.Background(), t)
+}
+
+// CreateDNSSecWithContext - same as CreateDNSSec, using ctx for cancellation and deadlines
+func (c Client) CreateDNSSecWithContext(ctx context.Context, t DNSSec) (*DNSSec, error) {
+	resp, err := c.post(ctx, "/tests/dns-dnssec/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create dns dnssec test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create dns dnssec test")
 	}
 	var target map[string][]DNSSec
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -103,24 +114,34 @@ func (c Client) CreateDNSSec(t DNSSec) (*DNSSec, error) {
 
 // DeleteDNSSec - delete DNSSec test
 func (c *Client) DeleteDNSSec(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-dnssec/%d/delete", id), nil, nil)
+	return c.DeleteDNSSecWithContext(context.Background(), id)
+}
+
+// DeleteDNSSecWithContext - same as DeleteDNSSec, using ctx for cancellation and deadlines
+func (c *Client) DeleteDNSSecWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-dnssec/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete dnsp domain test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete dnsp domain test")
 	}
 	return nil
 }
 
 // UpdateDNSSec - update DNSSec test
 func (c *Client) UpdateDNSSec(id int64, t DNSSec) (*DNSSec, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-dnssec/%d/update", id), t, nil)
+	return c.UpdateDNSSecWithContext(context.Background(), id, t)
+}
+
+// UpdateDNSSecWithContext - same as UpdateDNSSec, using ctx for cancellation and deadlines
+func (c *Client) UpdateDNSSecWithContext(ctx context.Context, id int64, t DNSSec) (*DNSSec, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-dnssec/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]DNSSec
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
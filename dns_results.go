@@ -0,0 +1,266 @@
+package thousandeyes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DNSServerResults - results of a DNS server test
+type DNSServerResults struct {
+	Test    GenericTest
+	Results []DNSServerResult
+}
+
+// DNSServerResult - the answer of a DNS server to an agent in a round.
+// ResolutionTime is in milliseconds.
+type DNSServerResult struct {
+	AgentID        *int64        `json:"agentId,omitempty"`
+	AgentName      *string       `json:"agentName,omitempty"`
+	CountryID      *string       `json:"countryId,omitempty"`
+	Date           *string       `json:"date,omitempty"`
+	RoundID        *int64        `json:"roundId,omitempty"`
+	Server         *string       `json:"server,omitempty"`
+	ServerIP       *string       `json:"serverIp,omitempty"`
+	ResolutionTime *float64      `json:"resolutionTime,omitempty"`
+	Mappings       *[]DNSMapping `json:"mappings,omitempty"`
+	ErrorDetails   *string       `json:"errorDetails,omitempty"`
+	Permalink      *string       `json:"permalink,omitempty"`
+}
+
+// DNSMapping - a record returned by a DNS server
+type DNSMapping struct {
+	Mapping *string `json:"mapping,omitempty"`
+	Type    *string `json:"type,omitempty"`
+	TTL     *int    `json:"ttl,omitempty"`
+}
+
+// DNSTraceResults - results of a DNS trace test
+type DNSTraceResults struct {
+	Test    GenericTest
+	Results []DNSTraceResult
+}
+
+// DNSTraceResult - a trace of the delegation chain by an agent in a
+// round.  ResolutionTime is in milliseconds.
+type DNSTraceResult struct {
+	AgentID            *int64           `json:"agentId,omitempty"`
+	AgentName          *string          `json:"agentName,omitempty"`
+	CountryID          *string          `json:"countryId,omitempty"`
+	Date               *string          `json:"date,omitempty"`
+	RoundID            *int64           `json:"roundId,omitempty"`
+	FinalServerQueried *string          `json:"finalServerQueried,omitempty"`
+	FinalRecords       *[]DNSMapping    `json:"finalRecords,omitempty"`
+	NumQueries         *int             `json:"numQueries,omitempty"`
+	ResolutionTime     *float64         `json:"resolutionTime,omitempty"`
+	Queries            *[]DNSTraceQuery `json:"queries,omitempty"`
+	ErrorDetails       *string          `json:"errorDetails,omitempty"`
+	Permalink          *string          `json:"permalink,omitempty"`
+}
+
+// DNSTraceQuery - a query of the delegation chain, with the referral or
+// records the server answered.  Duration is in milliseconds.
+type DNSTraceQuery struct {
+	Server    *string       `json:"server,omitempty"`
+	ServerIP  *string       `json:"serverIp,omitempty"`
+	QueryName *string       `json:"queryName,omitempty"`
+	Zone      *string       `json:"zone,omitempty"`
+	Referrals *[]string     `json:"referrals,omitempty"`
+	Records   *[]DNSMapping `json:"records,omitempty"`
+	Duration  *float64      `json:"duration,omitempty"`
+}
+
+// DNSSecResults - results of a DNSSEC test
+type DNSSecResults struct {
+	Test    GenericTest
+	Results []DNSSecResult
+}
+
+// DNSSecResult - the DNSSEC validation of an agent in a round
+type DNSSecResult struct {
+	AgentID      *int64  `json:"agentId,omitempty"`
+	AgentName    *string `json:"agentName,omitempty"`
+	CountryID    *string `json:"countryId,omitempty"`
+	Date         *string `json:"date,omitempty"`
+	RoundID      *int64  `json:"roundId,omitempty"`
+	Valid        *bool   `json:"valid,omitempty" te:"int-bool"`
+	ErrorDetails *string `json:"errorDetails,omitempty"`
+	Permalink    *string `json:"permalink,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It ensures
+// that ThousandEyes int fields that only use the values 0 or 1 are
+// treated as booleans.
+func (r *DNSSecResult) UnmarshalJSON(data []byte) error {
+	type alias DNSSecResult
+	result := (*alias)(r)
+
+	data, err := jsonIntToBool(r, data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &result)
+}
+
+// DNSMismatch - the differing answers of DNS servers to an agent in a
+// round, keyed by server
+type DNSMismatch struct {
+	AgentID *int64
+	RoundID *int64
+	Answers map[string][]string
+}
+
+// Answers - returns the sorted mappings of the result
+func (r DNSServerResult) Answers() []string {
+	answers := []string{}
+	if r.Mappings != nil {
+		for _, m := range *r.Mappings {
+			answers = append(answers, stringValue(m.Mapping))
+		}
+	}
+	sort.Strings(answers)
+	return answers
+}
+
+// Mismatches - returns the agent rounds where the DNS servers did not
+// all return the same answers, ordered by round and agent.  Results with
+// errors are ignored.
+func (r DNSServerResults) Mismatches() []DNSMismatch {
+	type key struct{ agent, round int64 }
+	groups := map[key]*DNSMismatch{}
+	keys := []key{}
+	for _, result := range r.Results {
+		if result.ErrorDetails != nil && *result.ErrorDetails != "" {
+			continue
+		}
+		k := key{int64Value(result.AgentID), int64Value(result.RoundID)}
+		group, ok := groups[k]
+		if !ok {
+			group = &DNSMismatch{AgentID: result.AgentID, RoundID: result.RoundID, Answers: map[string][]string{}}
+			groups[k] = group
+			keys = append(keys, k)
+		}
+		group.Answers[stringValue(result.Server)] = result.Answers()
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].round != keys[j].round {
+			return keys[i].round < keys[j].round
+		}
+		return keys[i].agent < keys[j].agent
+	})
+	mismatches := []DNSMismatch{}
+	for _, k := range keys {
+		distinct := map[string]bool{}
+		for _, answers := range groups[k].Answers {
+			distinct[strings.Join(answers, "\n")] = true
+		}
+		if len(distinct) > 1 {
+			mismatches = append(mismatches, *groups[k])
+		}
+	}
+	return mismatches
+}
+
+// GetDNSServerResults - Get results of a DNS server test
+func (c *Client) GetDNSServerResults(testID int64, opts *ResultsOptions) (*DNSServerResults, error) {
+	return c.GetDNSServerResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetDNSServerResultsWithContext - same as GetDNSServerResults, using ctx for cancellation and deadlines
+func (c *Client) GetDNSServerResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*DNSServerResults, error) {
+	results := DNSServerResults{Results: []DNSServerResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/dns/server/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			DNS struct {
+				Test   GenericTest       `json:"test"`
+				Server []DNSServerResult `json:"server"`
+			} `json:"dns"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.DNS.Test
+		for _, r := range target.DNS.Server {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// GetDNSTraceResults - Get results of a DNS trace test
+func (c *Client) GetDNSTraceResults(testID int64, opts *ResultsOptions) (*DNSTraceResults, error) {
+	return c.GetDNSTraceResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetDNSTraceResultsWithContext - same as GetDNSTraceResults, using ctx for cancellation and deadlines
+func (c *Client) GetDNSTraceResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*DNSTraceResults, error) {
+	results := DNSTraceResults{Results: []DNSTraceResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/dns/trace/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			DNS struct {
+				Test  GenericTest      `json:"test"`
+				Trace []DNSTraceResult `json:"trace"`
+			} `json:"dns"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.DNS.Test
+		for _, r := range target.DNS.Trace {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// GetDNSSecResults - Get results of a DNSSEC test
+func (c *Client) GetDNSSecResults(testID int64, opts *ResultsOptions) (*DNSSecResults, error) {
+	return c.GetDNSSecResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetDNSSecResultsWithContext - same as GetDNSSecResults, using ctx for cancellation and deadlines
+func (c *Client) GetDNSSecResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*DNSSecResults, error) {
+	results := DNSSecResults{Results: []DNSSecResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/dns/dnssec/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			DNS struct {
+				Test   GenericTest    `json:"test"`
+				DNSSec []DNSSecResult `json:"dnssec"`
+			} `json:"dns"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.DNS.Test
+		for _, r := range target.DNS.DNSSec {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
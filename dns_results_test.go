@@ -0,0 +1,109 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetDNSServerResults(t *testing.T) {
+	out := `{"dns": {"test": {"testId": 1, "type": "dns-server"}, "server": [
+		{"agentId": 10, "roundId": 100, "server": "ns1.example.com.", "serverIp": "192.0.2.53", "resolutionTime": 12,
+		 "mappings": [{"mapping": "192.0.2.1", "type": "A", "ttl": 300}]},
+		{"agentId": 10, "roundId": 100, "server": "ns2.example.com.", "resolutionTime": 15,
+		 "mappings": [{"mapping": "192.0.2.2", "type": "A"}]}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/dns/server/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "3h", r.URL.Query().Get("window"))
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetDNSServerResults(1, &ResultsOptions{Window: "3h"})
+	assert.Nil(t, err)
+	assert.Equal(t, DNSServerResult{
+		AgentID:        Int64(10),
+		RoundID:        Int64(100),
+		Server:         String("ns1.example.com."),
+		ServerIP:       String("192.0.2.53"),
+		ResolutionTime: Float64(12),
+		Mappings:       &[]DNSMapping{{Mapping: String("192.0.2.1"), Type: String("A"), TTL: Int(300)}},
+	}, res.Results[0])
+	assert.Len(t, res.Mismatches(), 1)
+}
+
+func TestClient_GetDNSTraceResults(t *testing.T) {
+	out := `{"dns": {"test": {"testId": 2, "type": "dns-trace"}, "trace": [
+		{"agentId": 10, "roundId": 100, "finalServerQueried": "ns1.example.com.", "numQueries": 3, "resolutionTime": 80,
+		 "finalRecords": [{"mapping": "192.0.2.1", "type": "A"}],
+		 "queries": [
+			{"server": "a.root-servers.net.", "queryName": "www.example.com.", "zone": ".", "referrals": ["a.gtld-servers.net."], "duration": 20},
+			{"server": "a.gtld-servers.net.", "zone": "com.", "referrals": ["ns1.example.com."], "duration": 30},
+			{"server": "ns1.example.com.", "zone": "example.com.", "records": [{"mapping": "192.0.2.1", "type": "A"}], "duration": 30}
+		 ]}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/dns/trace/2.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetDNSTraceResults(2, nil)
+	assert.Nil(t, err)
+	trace := res.Results[0]
+	assert.Equal(t, Int(3), trace.NumQueries)
+	assert.Len(t, *trace.Queries, 3)
+	assert.Equal(t, &[]string{"ns1.example.com."}, (*trace.Queries)[1].Referrals)
+	assert.Equal(t, String("192.0.2.1"), (*(*trace.Queries)[2].Records)[0].Mapping)
+}
+
+func TestClient_GetDNSSecResults(t *testing.T) {
+	out := `{"dns": {"test": {"testId": 3, "type": "dns-dnssec"}, "dnssec": [
+		{"agentId": 10, "roundId": 100, "valid": 1},
+		{"agentId": 20, "roundId": 100, "valid": 0, "errorDetails": "RRSIG expired"}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/dns/dnssec/3.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetDNSSecResults(3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []DNSSecResult{
+		{AgentID: Int64(10), RoundID: Int64(100), Valid: Bool(true)},
+		{AgentID: Int64(20), RoundID: Int64(100), Valid: Bool(false), ErrorDetails: String("RRSIG expired")},
+	}, res.Results)
+}
+
+func TestDNSServerResults_Mismatches(t *testing.T) {
+	a := &[]DNSMapping{{Mapping: String("192.0.2.1")}, {Mapping: String("192.0.2.2")}}
+	reversed := &[]DNSMapping{{Mapping: String("192.0.2.2")}, {Mapping: String("192.0.2.1")}}
+	b := &[]DNSMapping{{Mapping: String("198.51.100.1")}}
+	results := DNSServerResults{Results: []DNSServerResult{
+		{AgentID: Int64(10), RoundID: Int64(200), Server: String("ns1"), Mappings: a},
+		{AgentID: Int64(10), RoundID: Int64(200), Server: String("ns2"), Mappings: b},
+		{AgentID: Int64(10), RoundID: Int64(100), Server: String("ns1"), Mappings: a},
+		{AgentID: Int64(10), RoundID: Int64(100), Server: String("ns2"), Mappings: reversed},
+		{AgentID: Int64(20), RoundID: Int64(100), Server: String("ns1"), Mappings: a},
+		{AgentID: Int64(20), RoundID: Int64(100), Server: String("ns2"), ErrorDetails: String("Timeout")},
+	}}
+
+	assert.Equal(t, []DNSMismatch{{
+		AgentID: Int64(10),
+		RoundID: Int64(200),
+		Answers: map[string][]string{
+			"ns1": {"192.0.2.1", "192.0.2.2"},
+			"ns2": {"198.51.100.1"},
+		},
+	}}, results.Mismatches())
+	assert.Equal(t, []DNSMismatch{}, DNSServerResults{}.Mismatches())
+}
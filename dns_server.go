@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -93,7 +94,12 @@ func (t *DNSServer) AddAlertRule(id int64) {
 
 // GetDNSServer - get dns server test
 func (c *Client) GetDNSServer(id int64) (*DNSServer, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetDNSServerWithContext(context.Background(), id)
+}
+
+// GetDNSServerWithContext - same as GetDNSServer, using ctx for cancellation and deadlines
+func (c *Client) GetDNSServerWithContext(ctx context.Context, id int64) (*DNSServer, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &DNSServer{}, err
 	}
@@ -106,12 +112,17 @@ func (c *Client) GetDNSServer(id int64) (*DNSServer, error) {
 
 // CreateDNSServer - Create dns server test
 func (c Client) CreateDNSServer(t DNSServer) (*DNSServer, error) {
-	resp, err := c.post("/tests/dns-server/new", t, nil)
+	return c.CreateDNSServerWithContext(context.Background(), t)
+}
+
+// CreateDNSServerWithContext - same as CreateDNSServer, using ctx for cancellation and deadlines
+func (c Client) CreateDNSServerWithContext(ctx context.Context, t DNSServer) (*DNSServer, error) {
+	resp, err := c.post(ctx, "/tests/dns-server/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create test")
 	}
 	var target map[string][]DNSServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -122,24 +133,34 @@ func (c Client) CreateDNSServer(t DNSServer) (*DNSServer, error) {
 
 // DeleteDNSServer - delete dns server test
 func (c *Client) DeleteDNSServer(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-server/%d/delete", id), nil, nil)
+	return c.DeleteDNSServerWithContext(context.Background(), id)
+}
+
+// DeleteDNSServerWithContext - same as DeleteDNSServer, using ctx for cancellation and deadlines
+func (c *Client) DeleteDNSServerWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-server/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete dns server test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete dns server test")
 	}
 	return nil
 }
 
 // UpdateDNSServer - - Update dns server test
 func (c *Client) UpdateDNSServer(id int64, t DNSServer) (*DNSServer, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-server/%d/update", id), t, nil)
+	return c.UpdateDNSServerWithContext(context.Background(), id, t)
+}
+
+// UpdateDNSServerWithContext - same as UpdateDNSServer, using ctx for cancellation and deadlines
+func (c *Client) UpdateDNSServerWithContext(ctx context.Context, id int64, t DNSServer) (*DNSServer, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-server/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]DNSServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -75,7 +76,12 @@ func (t *DNSTrace) AddAlertRule(id int64) {
 
 // GetDNSTrace - get dns trace test
 func (c *Client) GetDNSTrace(id int64) (*DNSTrace, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetDNSTraceWithContext(context.Background(), id)
+}
+
+// GetDNSTraceWithContext - same as GetDNSTrace, using ctx for cancellation and deadlines
+func (c *Client) GetDNSTraceWithContext(ctx context.Context, id int64) (*DNSTrace, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &DNSTrace{}, err
 	}
@@ -88,12 +94,17 @@ func (c *Client) GetDNSTrace(id int64) (*DNSTrace, error) {
 
 // CreateDNSTrace - Create dns trace test
 func (c Client) CreateDNSTrace(t DNSTrace) (*DNSTrace, error) {
-	resp, err := c.post("/tests/dns-trace/new", t, nil)
+	return c.CreateDNSTraceWithContext(context.Background(), t)
+}
+
+// CreateDNSTraceWithContext - same as CreateDNSTrace, using ctx for cancellation and deadlines
+func (c Client) CreateDNSTraceWithContext(ctx context.Context, t DNSTrace) (*DNSTrace, error) {
+	resp, err := c.post(ctx, "/tests/dns-trace/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create test")
 	}
 	var target map[string][]DNSTrace
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -104,24 +115,34 @@ func (c Client) CreateDNSTrace(t DNSTrace) (*DNSTrace, error) {
 
 // DeleteDNSTrace - delete dns trace test
 func (c *Client) DeleteDNSTrace(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-trace/%d/delete", id), nil, nil)
+	return c.DeleteDNSTraceWithContext(context.Background(), id)
+}
+
+// DeleteDNSTraceWithContext - same as DeleteDNSTrace, using ctx for cancellation and deadlines
+func (c *Client) DeleteDNSTraceWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-trace/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete dns trace test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete dns trace test")
 	}
 	return nil
 }
 
 // UpdateDNSTrace - update dns trace test
 func (c *Client) UpdateDNSTrace(id int64, t DNSTrace) (*DNSTrace, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/dns-trace/%d/update", id), t, nil)
+	return c.UpdateDNSTraceWithContext(context.Background(), id, t)
+}
+
+// UpdateDNSTraceWithContext - same as UpdateDNSTrace, using ctx for cancellation and deadlines
+func (c *Client) UpdateDNSTraceWithContext(ctx context.Context, id int64, t DNSTrace) (*DNSTrace, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/dns-trace/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]DNSTrace
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -0,0 +1,106 @@
+package thousandeyes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// APIError - error returned when the API responds with an unexpected HTTP
+// status.  Use errors.As to retrieve it from errors returned by Client
+// methods, or the IsNotFound, IsRateLimited and similar helpers.
+type APIError struct {
+	// StatusCode is the HTTP response code
+	StatusCode int
+	// ErrorMessage is the errorMessage reported by the API, if any
+	ErrorMessage string
+	// Method and Path identify the request which failed
+	Method string
+	Path   string
+	// RateLimit holds the organization rate limit headers of the response
+	RateLimit RateLimit
+	// Header holds the full response headers
+	Header http.Header
+
+	message string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	if e.ErrorMessage != "" {
+		return fmt.Sprintf("%s %s: HTTP response code: %v. Error: %s", e.Method, e.Path, e.StatusCode, e.ErrorMessage)
+	}
+	return fmt.Sprintf("%s %s: HTTP response code: %v", e.Method, e.Path, e.StatusCode)
+}
+
+// NewAPIError builds an APIError describing resp, such as a response
+// with a status code the caller did not expect
+func NewAPIError(resp *http.Response) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.Path = resp.Request.URL.Path
+	}
+	// We discard errors, because an error or blank result also return 0
+	e.RateLimit.Limit, _ = strconv.ParseInt(resp.Header.Get("X-Organization-Rate-Limit-Limit"), 10, 64)
+	e.RateLimit.Remaining, _ = strconv.ParseInt(resp.Header.Get("X-Organization-Rate-Limit-Remaining"), 10, 64)
+	e.RateLimit.Reset = parseReset(resp.Header.Get("X-Organization-Rate-Limit-Reset"))
+	return e
+}
+
+// unexpectedStatus returns an APIError for a successful response which
+// does not carry the status code expected by the operation
+func unexpectedStatus(resp *http.Response, operation string) *APIError {
+	e := NewAPIError(resp)
+	e.message = fmt.Sprintf("%s, response code %d", operation, resp.StatusCode)
+	return e
+}
+
+// hasStatus reports whether err is an APIError with the given status code
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// IsBadRequest reports whether err is an APIError for a request which
+// failed validation
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized reports whether err is an APIError for a request with
+// missing or invalid credentials
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError for a request the
+// credentials are not permitted to make
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is an APIError for a missing resource
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError for a request rejected
+// by rate limiting
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError reports whether err is an APIError for a failure on the
+// API side
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
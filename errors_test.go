@@ -0,0 +1,86 @@
+package thousandeyes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_APIError(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/tests/1.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Organization-Rate-Limit-Limit", "240")
+		w.Header().Set("X-Organization-Rate-Limit-Remaining", "100")
+		w.Header().Set("X-Organization-Rate-Limit-Reset", "120")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorMessage": "Test not found"}`))
+	})
+
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL})
+	_, err := client.GetHTTPServer(1)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Test not found", apiErr.ErrorMessage)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, "/tests/1.json", apiErr.Path)
+	assert.Equal(t, RateLimit{Limit: 240, Remaining: 100, Reset: 120}, apiErr.RateLimit)
+	assert.EqualError(t, err, "Failed call API endpoint. HTTP response code: 404. Error: Test not found")
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsRateLimited(err))
+}
+
+func TestClient_APIErrorUnexpectedStatus(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/tests/http-server/1/delete.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL})
+	err := client.DeleteHTTPServer(1)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "POST", apiErr.Method)
+	assert.EqualError(t, err, "failed to delete http server, response code 200")
+}
+
+func TestNewAPIError(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Organization-Rate-Limit-Reset", "2022-03-01T10:00:00Z")
+	// Responses built by custom HTTP clients may have no request
+	apiErr := NewAPIError(&http.Response{StatusCode: http.StatusOK, Header: header})
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "", apiErr.Method)
+	assert.Equal(t, int64(1646128800), apiErr.RateLimit.Reset)
+}
+
+func TestAPIError_Helpers(t *testing.T) {
+	for code, check := range map[int]func(error) bool{
+		400: IsBadRequest,
+		401: IsUnauthorized,
+		403: IsForbidden,
+		404: IsNotFound,
+		429: IsRateLimited,
+		503: IsServerError,
+	} {
+		err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: code})
+		assert.True(t, check(err), "status %d", code)
+		assert.False(t, check(&APIError{StatusCode: 200}), "status %d", code)
+		assert.False(t, check(errors.New("other")), "status %d", code)
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	err := &APIError{StatusCode: 400, Method: "POST", Path: "/v6/tests.json", ErrorMessage: "bad"}
+	assert.EqualError(t, err, "POST /v6/tests.json: HTTP response code: 400. Error: bad")
+	err.ErrorMessage = ""
+	assert.EqualError(t, err, "POST /v6/tests.json: HTTP response code: 400")
+}
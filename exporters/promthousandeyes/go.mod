@@ -0,0 +1,31 @@
+module github.com/thousandeyes/thousandeyes-sdk-go/exporters/promthousandeyes
+
+// github.com/prometheus/client_golang v1.24.1 requires Go 1.25, while the
+// SDK module itself still supports Go 1.17.
+go 1.25.0
+
+require (
+	github.com/prometheus/client_golang v1.24.1
+	github.com/stretchr/testify v1.12.1
+	github.com/thousandeyes/thousandeyes-sdk-go/v2 v2.0.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.70.1 // indirect
+	github.com/prometheus/procfs v0.21.1 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+// This module is only built within the repository, against the SDK next
+// to it: the SDK's results APIs it uses are not part of a tagged
+// release yet, so the version required above is a placeholder which the
+// replace directive overrides.  It must be raised to the first release
+// with those APIs before the module can be used outside the repository.
+replace github.com/thousandeyes/thousandeyes-sdk-go/v2 => ../../
@@ -0,0 +1,458 @@
+// Package promthousandeyes exports ThousandEyes test results as Prometheus
+// metrics.  The latest round of every test is fetched in the background
+// through the SDK client, so the client's rate limiter and retry policy
+// apply, and scrapes are served from the last fetch without calling the
+// API.
+//
+//	client := thousandeyes.NewClient(&thousandeyes.ClientOptions{AuthToken: token})
+//	collector := promthousandeyes.NewCollector(client)
+//	go collector.Run(ctx)
+//	http.Handle("/metrics", collector.Handler())
+//
+// Metrics are labelled with the test ID, name and type, the agent, and the
+// test's group labels, joined with commas.  Times are in seconds and
+// losses are ratios, following Prometheus conventions.
+package promthousandeyes
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+// Namespace prefixes the names of the exported metrics
+const Namespace = "thousandeyes"
+
+// defaultTimeout bounds the API calls of a refresh
+const defaultTimeout = 30 * time.Second
+
+// defaultRefreshInterval is the time between refreshes when none is set
+const defaultRefreshInterval = time.Minute
+
+// baseLabels label every metric of a test
+var baseLabels = []string{"test_id", "test_name", "test_type", "groups"}
+
+// agentLabels label every result metric
+var agentLabels = append(append([]string{}, baseLabels...), "agent_id", "agent")
+
+func newDesc(subsystem, name, help string, extra ...string) *prometheus.Desc {
+	labels := append(append([]string{}, agentLabels...), extra...)
+	return prometheus.NewDesc(prometheus.BuildFQName(Namespace, subsystem, name), help, labels, nil)
+}
+
+var (
+	scrapeSuccessDesc = prometheus.NewDesc(prometheus.BuildFQName(Namespace, "", "scrape_success"),
+		"Whether the results of the test were fetched", baseLabels, nil)
+
+	netLossDesc = newDesc("net", "loss_ratio",
+		"Packet loss from the agent to the target", "server", "direction")
+	netLatencyDesc = newDesc("net", "latency_seconds",
+		"Average latency from the agent to the target", "server", "direction")
+	netJitterDesc = newDesc("net", "jitter_seconds",
+		"Jitter from the agent to the target", "server", "direction")
+	netThroughputDesc = newDesc("net", "throughput_bits_per_second",
+		"Throughput between agents", "server", "direction")
+
+	httpResponseCodeDesc = newDesc("http", "response_code",
+		"HTTP response code of the server", "server")
+	httpDurationDesc = newDesc("http", "duration_seconds",
+		"Time of each phase of the HTTP request", "server", "phase")
+	httpThroughputDesc = newDesc("http", "throughput_bytes_per_second",
+		"Throughput of the HTTP response", "server")
+
+	pageLoadDurationDesc = newDesc("page_load", "duration_seconds",
+		"Time to load the DOM and the complete page", "phase")
+	pageLoadObjectsDesc = newDesc("page_load", "objects",
+		"Number of components loaded by the page")
+	pageLoadErrorsDesc = newDesc("page_load", "errors",
+		"Number of components which failed to load")
+
+	dnsResolutionDesc = newDesc("dns", "resolution_seconds",
+		"Time the DNS server took to resolve the domain", "server")
+
+	voiceMOSDesc = newDesc("voice", "mos",
+		"Mean opinion score of the RTP stream", "target_agent")
+	voiceLossDesc = newDesc("voice", "loss_ratio",
+		"Packet loss of the RTP stream", "target_agent")
+	voiceDiscardsDesc = newDesc("voice", "discards_ratio",
+		"Packets of the RTP stream discarded by the jitter buffer", "target_agent")
+	voiceJitterDesc = newDesc("voice", "jitter_seconds",
+		"Packet delay variation of the RTP stream", "target_agent")
+	voiceLatencyDesc = newDesc("voice", "latency_seconds",
+		"Latency of the RTP stream", "target_agent")
+)
+
+// Option - configures a Collector
+type Option func(*Collector)
+
+// WithTestIDs restricts the collector to the given tests, all tests by
+// default
+func WithTestIDs(ids ...int64) Option {
+	return func(c *Collector) {
+		c.testIDs = map[int64]bool{}
+		for _, id := range ids {
+			c.testIDs[id] = true
+		}
+	}
+}
+
+// WithTimeout bounds the API calls of a refresh, 30 seconds by default
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Collector) {
+		c.timeout = timeout
+	}
+}
+
+// WithRefreshInterval sets the time between refreshes of Run, one minute
+// by default
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(c *Collector) {
+		c.interval = interval
+	}
+}
+
+// Collector - prometheus.Collector serving the latest results of tests.
+// Results are fetched by Refresh, or periodically by Run, and Collect only
+// reads the metrics of the last refresh, so that scrapes do not call the
+// API.
+type Collector struct {
+	client   *thousandeyes.Client
+	testIDs  map[int64]bool
+	timeout  time.Duration
+	interval time.Duration
+
+	mu      sync.RWMutex
+	metrics []prometheus.Metric
+}
+
+// NewCollector returns a Collector fetching results with client.  It
+// exports no metrics until Run or Refresh is called.
+func NewCollector(client *thousandeyes.Client, opts ...Option) *Collector {
+	c := &Collector{client: client, timeout: defaultTimeout, interval: defaultRefreshInterval}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Handler returns an http.Handler serving the collector's metrics, to be
+// mounted on /metrics
+func (c *Collector) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Describe - Satisfying the prometheus.Collector interface
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		scrapeSuccessDesc,
+		netLossDesc, netLatencyDesc, netJitterDesc, netThroughputDesc,
+		httpResponseCodeDesc, httpDurationDesc, httpThroughputDesc,
+		pageLoadDurationDesc, pageLoadObjectsDesc, pageLoadErrorsDesc,
+		dnsResolutionDesc,
+		voiceMOSDesc, voiceLossDesc, voiceDiscardsDesc, voiceJitterDesc, voiceLatencyDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect - Satisfying the prometheus.Collector interface
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	metrics := c.metrics
+	c.mu.RUnlock()
+	for _, m := range metrics {
+		ch <- m
+	}
+}
+
+// Run - refreshes the metrics, then again after each refresh interval,
+// until ctx is done.  Failed refreshes are reported by the next scrapes.
+func (c *Collector) Run(ctx context.Context) error {
+	interval := c.interval
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	for {
+		_ = c.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Refresh - fetches the latest results of the tests, replacing the metrics
+// served by Collect.  When the tests cannot be listed, the error is
+// returned and scrapes fail until the next refresh.  Tests whose results
+// cannot be fetched have a scrape_success of 0.
+func (c *Collector) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var metrics []prometheus.Metric
+	tests, err := c.client.GetTestsWithContext(ctx)
+	if err != nil {
+		metrics = []prometheus.Metric{prometheus.NewInvalidMetric(scrapeSuccessDesc, err)}
+	} else {
+		metrics = c.collectTests(ctx, *tests)
+	}
+
+	c.mu.Lock()
+	c.metrics = metrics
+	c.mu.Unlock()
+	return err
+}
+
+// collectTests returns the metrics of the latest results of tests
+func (c *Collector) collectTests(ctx context.Context, tests []thousandeyes.GenericTest) []prometheus.Metric {
+	var metrics []prometheus.Metric
+	for _, test := range tests {
+		if test.TestID == nil || (c.testIDs != nil && !c.testIDs[*test.TestID]) {
+			continue
+		}
+		s := newSamples(test)
+		err := c.collectTest(ctx, test, s)
+		success := 1.0
+		if err != nil {
+			success = 0
+		}
+		metrics = append(metrics, prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, s.base...))
+		metrics = s.emit(metrics)
+	}
+	return metrics
+}
+
+// collectTest adds the latest results of test to s
+func (c *Collector) collectTest(ctx context.Context, test thousandeyes.GenericTest, s *samples) error {
+	id := *test.TestID
+	switch value(test.Type) {
+	case "agent-to-server":
+		res, err := c.client.GetNetMetricsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, m := range res.Metrics {
+			server := value(m.Server)
+			agent := s.agent(m.AgentID, m.AgentName, m.RoundID, server)
+			agent.add(netLossDesc, ratio(m.Loss), server, "")
+			agent.add(netLatencyDesc, seconds(m.AvgLatency), server, "")
+			agent.add(netJitterDesc, seconds(m.Jitter), server, "")
+		}
+	case "agent-to-agent":
+		res, err := c.client.GetAgentAgentResultsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, m := range res.Results {
+			target, direction := value(m.TargetAgentName), value(m.Direction)
+			agent := s.agent(m.AgentID, m.AgentName, m.RoundID, target)
+			agent.add(netLossDesc, ratio(m.Loss), target, direction)
+			agent.add(netLatencyDesc, seconds(m.AvgLatency), target, direction)
+			agent.add(netJitterDesc, seconds(m.Jitter), target, direction)
+			agent.add(netThroughputDesc, m.Throughput, target, direction)
+		}
+	case "http-server":
+		res, err := c.client.GetHTTPServerResultsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, r := range res.Results {
+			server := value(r.Server)
+			agent := s.agent(r.AgentID, r.AgentName, r.RoundID, server)
+			if r.ResponseCode != nil {
+				code := float64(*r.ResponseCode)
+				agent.add(httpResponseCodeDesc, &code, server)
+			}
+			agent.add(httpDurationDesc, seconds(r.DNSTime), server, "dns")
+			agent.add(httpDurationDesc, seconds(r.ConnectTime), server, "connect")
+			agent.add(httpDurationDesc, seconds(r.SSLTime), server, "ssl")
+			agent.add(httpDurationDesc, seconds(r.WaitTime), server, "wait")
+			agent.add(httpDurationDesc, seconds(r.ReceiveTime), server, "receive")
+			agent.add(httpDurationDesc, seconds(r.TotalTime), server, "total")
+			agent.add(httpThroughputDesc, r.Throughput, server)
+		}
+	case "page-load":
+		res, err := c.client.GetPageLoadResultsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, r := range res.Results {
+			agent := s.agent(r.AgentID, r.AgentName, r.RoundID, "")
+			agent.add(pageLoadDurationDesc, seconds(r.DOMLoadTime), "dom_load")
+			agent.add(pageLoadDurationDesc, seconds(r.PageLoadTime), "page_load")
+			agent.add(pageLoadObjectsDesc, count(r.NumObjects))
+			agent.add(pageLoadErrorsDesc, count(r.NumErrors))
+		}
+	case "dns-server":
+		res, err := c.client.GetDNSServerResultsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, r := range res.Results {
+			server := value(r.Server)
+			agent := s.agent(r.AgentID, r.AgentName, r.RoundID, server)
+			agent.add(dnsResolutionDesc, seconds(r.ResolutionTime), server)
+		}
+	case "voice":
+		res, err := c.client.GetRTPStreamResultsWithContext(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+		for _, r := range res.Results {
+			target := value(r.TargetAgentName)
+			agent := s.agent(r.AgentID, r.AgentName, r.RoundID, target)
+			agent.add(voiceMOSDesc, r.MOS, target)
+			agent.add(voiceLossDesc, ratio(r.Loss), target)
+			agent.add(voiceDiscardsDesc, ratio(r.Discards), target)
+			agent.add(voiceJitterDesc, seconds(r.Jitter), target)
+			agent.add(voiceLatencyDesc, seconds(r.Latency), target)
+		}
+	}
+	return nil
+}
+
+// samples collects the metrics of a test.  The API may return several
+// rounds, so only the latest round of each agent and server (or target
+// agent) is kept, with the fields that round reports: a field missing from
+// the latest round is not filled in from an earlier one.
+type samples struct {
+	base   []string
+	series map[string]*series
+	keys   []string
+}
+
+// series holds the samples of an agent and server in its latest round
+type series struct {
+	round   int64
+	samples map[string]sample
+	keys    []string
+}
+
+type sample struct {
+	desc   *prometheus.Desc
+	value  float64
+	labels []string
+}
+
+// agentSamples adds samples for an agent and server in a round.  Its
+// series is nil when a later round was seen, so that add ignores them.
+type agentSamples struct {
+	series *series
+	labels []string
+}
+
+func newSamples(test thousandeyes.GenericTest) *samples {
+	groups := []string{}
+	if test.Groups != nil {
+		for _, g := range *test.Groups {
+			if g.Name != nil {
+				groups = append(groups, *g.Name)
+			}
+		}
+	}
+	sort.Strings(groups)
+	return &samples{
+		base: []string{
+			strconv.FormatInt(*test.TestID, 10),
+			value(test.TestName),
+			value(test.Type),
+			strings.Join(groups, ","),
+		},
+		series: map[string]*series{},
+	}
+}
+
+// agent returns the samples of an agent and server in a round, dropping
+// those of earlier rounds
+func (s *samples) agent(id *int64, name *string, round *int64, server string) agentSamples {
+	labels := append(append([]string{}, s.base...), "", value(name))
+	if id != nil {
+		labels[len(s.base)] = strconv.FormatInt(*id, 10)
+	}
+	var r int64
+	if round != nil {
+		r = *round
+	}
+	key := strings.Join([]string{labels[len(s.base)], labels[len(s.base)+1], server}, "\xff")
+	current, ok := s.series[key]
+	switch {
+	case !ok:
+		current = &series{round: r, samples: map[string]sample{}}
+		s.series[key] = current
+		s.keys = append(s.keys, key)
+	case r > current.round:
+		*current = series{round: r, samples: map[string]sample{}}
+	case r < current.round:
+		return agentSamples{labels: labels}
+	}
+	return agentSamples{series: current, labels: labels}
+}
+
+// add records v, unless nil or from a superseded round
+func (a agentSamples) add(desc *prometheus.Desc, v *float64, extra ...string) {
+	if v == nil || a.series == nil {
+		return
+	}
+	labels := append(append([]string{}, a.labels...), extra...)
+	key := desc.String() + "\xff" + strings.Join(labels, "\xff")
+	if _, ok := a.series.samples[key]; !ok {
+		a.series.keys = append(a.series.keys, key)
+	}
+	a.series.samples[key] = sample{desc: desc, value: *v, labels: labels}
+}
+
+// emit appends the metrics of the samples to metrics
+func (s *samples) emit(metrics []prometheus.Metric) []prometheus.Metric {
+	for _, key := range s.keys {
+		series := s.series[key]
+		for _, key := range series.keys {
+			sample := series.samples[key]
+			metrics = append(metrics, prometheus.MustNewConstMetric(sample.desc, prometheus.GaugeValue, sample.value, sample.labels...))
+		}
+	}
+	return metrics
+}
+
+func value(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// seconds converts milliseconds to seconds
+func seconds(ms *float64) *float64 {
+	if ms == nil {
+		return nil
+	}
+	s := *ms / 1000
+	return &s
+}
+
+// ratio converts a percentage to a ratio
+func ratio(percent *float64) *float64 {
+	if percent == nil {
+		return nil
+	}
+	r := *percent / 100
+	return &r
+}
+
+func count(n *int) *float64 {
+	if n == nil {
+		return nil
+	}
+	f := float64(*n)
+	return &f
+}
@@ -0,0 +1,177 @@
+package promthousandeyes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+// api stands in for the ThousandEyes API, counting the requests
+func api(t *testing.T) (*thousandeyes.Client, *int32) {
+	var requests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tests.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"test": [
+			{"testId": 1, "testName": "web", "type": "http-server", "groups": [{"name": "prod"}, {"name": "eu"}]},
+			{"testId": 2, "testName": "net", "type": "agent-to-server"},
+			{"testId": 3, "testName": "dns", "type": "dns-server"},
+			{"testId": 4, "testName": "call", "type": "voice"},
+			{"testId": 5, "testName": "broken", "type": "page-load"}
+		]}`))
+	})
+	mux.HandleFunc("/web/http-server/1.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"web": {"httpServer": [
+			{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "example.com:443", "responseCode": 200,
+			 "dnsTime": 5, "connectTime": 10, "totalTime": 250, "throughput": 1000},
+			{"agentId": 10, "agentName": "Dallas", "roundId": 200, "server": "example.com:443", "responseCode": 503,
+			 "totalTime": 500}
+		]}}`))
+	})
+	mux.HandleFunc("/net/metrics/2.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"net": {"metrics": [
+			{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "example.com:443", "loss": 2.5, "avgLatency": 40, "jitter": 1},
+			{"agentId": 10, "agentName": "Dallas", "roundId": 50, "server": "example.org:443", "loss": 5}
+		]}}`))
+	})
+	mux.HandleFunc("/dns/server/3.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"dns": {"server": [
+			{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "ns1.example.com.", "resolutionTime": 12}
+		]}}`))
+	})
+	mux.HandleFunc("/voice/metrics/4.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"voice": {"metrics": [
+			{"agentId": 10, "agentName": "Dallas", "targetAgentName": "London", "roundId": 100, "mos": 4.2, "loss": 1}
+		]}}`))
+	})
+	mux.HandleFunc("/web/page-load/5.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return thousandeyes.NewClient(&thousandeyes.ClientOptions{
+		APIEndpoint: server.URL,
+		Logger:      thousandeyes.NopLogger{},
+	}), &requests
+}
+
+// refreshed returns a collector whose metrics were refreshed
+func refreshed(t *testing.T, client *thousandeyes.Client, opts ...Option) *Collector {
+	collector := NewCollector(client, opts...)
+	require.NoError(t, collector.Refresh(context.Background()))
+	return collector
+}
+
+func TestCollector(t *testing.T) {
+	client, _ := api(t)
+	collector := refreshed(t, client)
+
+	expected := `
+# HELP thousandeyes_dns_resolution_seconds Time the DNS server took to resolve the domain
+# TYPE thousandeyes_dns_resolution_seconds gauge
+thousandeyes_dns_resolution_seconds{agent="Dallas",agent_id="10",groups="",server="ns1.example.com.",test_id="3",test_name="dns",test_type="dns-server"} 0.012
+# HELP thousandeyes_http_duration_seconds Time of each phase of the HTTP request
+# TYPE thousandeyes_http_duration_seconds gauge
+thousandeyes_http_duration_seconds{agent="Dallas",agent_id="10",groups="eu,prod",phase="total",server="example.com:443",test_id="1",test_name="web",test_type="http-server"} 0.5
+# HELP thousandeyes_http_response_code HTTP response code of the server
+# TYPE thousandeyes_http_response_code gauge
+thousandeyes_http_response_code{agent="Dallas",agent_id="10",groups="eu,prod",server="example.com:443",test_id="1",test_name="web",test_type="http-server"} 503
+# HELP thousandeyes_net_loss_ratio Packet loss from the agent to the target
+# TYPE thousandeyes_net_loss_ratio gauge
+thousandeyes_net_loss_ratio{agent="Dallas",agent_id="10",direction="",groups="",server="example.com:443",test_id="2",test_name="net",test_type="agent-to-server"} 0.025
+thousandeyes_net_loss_ratio{agent="Dallas",agent_id="10",direction="",groups="",server="example.org:443",test_id="2",test_name="net",test_type="agent-to-server"} 0.05
+# HELP thousandeyes_scrape_success Whether the results of the test were fetched
+# TYPE thousandeyes_scrape_success gauge
+thousandeyes_scrape_success{groups="",test_id="2",test_name="net",test_type="agent-to-server"} 1
+thousandeyes_scrape_success{groups="",test_id="3",test_name="dns",test_type="dns-server"} 1
+thousandeyes_scrape_success{groups="",test_id="4",test_name="call",test_type="voice"} 1
+thousandeyes_scrape_success{groups="",test_id="5",test_name="broken",test_type="page-load"} 0
+thousandeyes_scrape_success{groups="eu,prod",test_id="1",test_name="web",test_type="http-server"} 1
+# HELP thousandeyes_voice_mos Mean opinion score of the RTP stream
+# TYPE thousandeyes_voice_mos gauge
+thousandeyes_voice_mos{agent="Dallas",agent_id="10",groups="",target_agent="London",test_id="4",test_name="call",test_type="voice"} 4.2
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"thousandeyes_dns_resolution_seconds",
+		"thousandeyes_http_duration_seconds",
+		"thousandeyes_http_response_code",
+		"thousandeyes_net_loss_ratio",
+		"thousandeyes_scrape_success",
+		"thousandeyes_voice_mos",
+	)
+	assert.NoError(t, err)
+}
+
+func TestCollectorTestIDs(t *testing.T) {
+	client, _ := api(t)
+	collector := refreshed(t, client, WithTestIDs(3))
+	assert.Equal(t, 2, testutil.CollectAndCount(collector))
+}
+
+func TestCollectorCache(t *testing.T) {
+	client, requests := api(t)
+	collector := NewCollector(client)
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+	assert.Equal(t, int32(0), atomic.LoadInt32(requests))
+
+	require.NoError(t, collector.Refresh(context.Background()))
+	fetched := atomic.LoadInt32(requests)
+	assert.Equal(t, int32(6), fetched)
+	count := testutil.CollectAndCount(collector)
+	assert.Equal(t, count, testutil.CollectAndCount(collector))
+	assert.Equal(t, fetched, atomic.LoadInt32(requests))
+}
+
+func TestCollectorRefreshError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	collector := NewCollector(thousandeyes.NewClient(&thousandeyes.ClientOptions{
+		APIEndpoint: server.URL,
+		Logger:      thousandeyes.NopLogger{},
+	}))
+
+	assert.Error(t, collector.Refresh(context.Background()))
+	_, err := testutil.CollectAndLint(collector)
+	assert.Error(t, err)
+}
+
+func TestCollectorRun(t *testing.T) {
+	client, requests := api(t)
+	collector := NewCollector(client, WithRefreshInterval(10*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- collector.Run(ctx) }()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(requests) >= 12 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 2, testutil.CollectAndCount(collector, "thousandeyes_net_loss_ratio"))
+}
+
+func TestCollectorHandler(t *testing.T) {
+	client, _ := api(t)
+	exporter := httptest.NewServer(refreshed(t, client, WithTestIDs(4)).Handler())
+	defer exporter.Close()
+
+	resp, err := http.Get(exporter.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `thousandeyes_voice_loss_ratio{agent="Dallas",agent_id="10",groups="",target_agent="London",test_id="4",test_name="call",test_type="voice"} 0.01`)
+}
@@ -0,0 +1,76 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// FTPServerResults - results of an FTP server test
+type FTPServerResults struct {
+	Test    GenericTest
+	Results []FTPServerResult
+}
+
+// FTPServerResult - FTP server metrics measured by an agent in a round.
+// Times are in milliseconds and Throughput in bytes per second.
+type FTPServerResult struct {
+	AgentID         *int64   `json:"agentId,omitempty"`
+	AgentName       *string  `json:"agentName,omitempty"`
+	CountryID       *string  `json:"countryId,omitempty"`
+	Date            *string  `json:"date,omitempty"`
+	RoundID         *int64   `json:"roundId,omitempty"`
+	Server          *string  `json:"server,omitempty"`
+	ServerIP        *string  `json:"serverIp,omitempty"`
+	RequestType     *string  `json:"requestType,omitempty"`
+	ResponseCode    *int     `json:"responseCode,omitempty"`
+	ConnectTime     *float64 `json:"connectTime,omitempty"`
+	NegotiationTime *float64 `json:"negotiationTime,omitempty"`
+	WaitTime        *float64 `json:"waitTime,omitempty"`
+	TransferTime    *float64 `json:"transferTime,omitempty"`
+	ResponseTime    *float64 `json:"responseTime,omitempty"`
+	TotalTime       *float64 `json:"totalTime,omitempty"`
+	Throughput      *float64 `json:"throughput,omitempty"`
+	WireSize        *int64   `json:"wireSize,omitempty"`
+	ErrorType       *string  `json:"errorType,omitempty"`
+	ErrorDetails    *string  `json:"errorDetails,omitempty"`
+	Permalink       *string  `json:"permalink,omitempty"`
+}
+
+// Agent - returns the agent which measured the result
+func (r FTPServerResult) Agent() Agent {
+	return Agent{AgentID: r.AgentID, AgentName: r.AgentName, CountryID: r.CountryID}
+}
+
+// GetFTPServerResults - Get results of an FTP server test
+func (c *Client) GetFTPServerResults(testID int64, opts *ResultsOptions) (*FTPServerResults, error) {
+	return c.GetFTPServerResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetFTPServerResultsWithContext - same as GetFTPServerResults, using ctx for cancellation and deadlines
+func (c *Client) GetFTPServerResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*FTPServerResults, error) {
+	results := FTPServerResults{Results: []FTPServerResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/web/ftp-server/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Web struct {
+				Test      GenericTest       `json:"test"`
+				FTPServer []FTPServerResult `json:"ftpServer"`
+			} `json:"web"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Web.Test
+		for _, r := range target.Web.FTPServer {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
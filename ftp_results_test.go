@@ -0,0 +1,50 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetFTPServerResults(t *testing.T) {
+	out := `{"web": {"test": {"testId": 1, "type": "ftp-server"}, "ftpServer": [
+		{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "ftp.example.com:21", "serverIp": "192.0.2.21",
+		 "requestType": "Download", "responseCode": 226, "connectTime": 10, "negotiationTime": 40, "waitTime": 15,
+		 "transferTime": 300, "responseTime": 65, "totalTime": 365, "throughput": 3495253, "wireSize": 1048576},
+		{"agentId": 20, "agentName": "London", "roundId": 100, "responseCode": 530, "errorType": "Login",
+		 "errorDetails": "Login incorrect"}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/ftp-server/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "2022-03-01T10:00:00", r.URL.Query().Get("from"))
+		assert.Equal(t, "2022-03-01T11:00:00", r.URL.Query().Get("to"))
+		_, _ = w.Write([]byte(out))
+	})
+
+	from := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	res, err := client.GetFTPServerResults(1, &ResultsOptions{From: from, To: from.Add(time.Hour), AgentIDs: []int64{10}})
+	assert.Nil(t, err)
+	assert.Equal(t, []FTPServerResult{{
+		AgentID:         Int64(10),
+		AgentName:       String("Dallas"),
+		RoundID:         Int64(100),
+		Server:          String("ftp.example.com:21"),
+		ServerIP:        String("192.0.2.21"),
+		RequestType:     String("Download"),
+		ResponseCode:    Int(226),
+		ConnectTime:     Float64(10),
+		NegotiationTime: Float64(40),
+		WaitTime:        Float64(15),
+		TransferTime:    Float64(300),
+		ResponseTime:    Float64(65),
+		TotalTime:       Float64(365),
+		Throughput:      Float64(3495253),
+		WireSize:        Int64(1048576),
+	}}, res.Results)
+	assert.Equal(t, Agent{AgentID: Int64(10), AgentName: String("Dallas")}, res.Results[0].Agent())
+}
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -89,7 +90,12 @@ func (t *FTPServer) AddAlertRule(id int64) {
 
 // GetFTPServer - get ftp server test
 func (c *Client) GetFTPServer(id int64) (*FTPServer, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetFTPServerWithContext(context.Background(), id)
+}
+
+// GetFTPServerWithContext - same as GetFTPServer, using ctx for cancellation and deadlines
+func (c *Client) GetFTPServerWithContext(ctx context.Context, id int64) (*FTPServer, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &FTPServer{}, err
 	}
@@ -102,12 +108,17 @@ func (c *Client) GetFTPServer(id int64) (*FTPServer, error) {
 
 // CreateFTPServer - Create ftp server test
 func (c Client) CreateFTPServer(t FTPServer) (*FTPServer, error) {
-	resp, err := c.post("/tests/ftp-server/new", t, nil)
+	return c.CreateFTPServerWithContext(context.Background(), t)
+}
+
+// CreateFTPServerWithContext - same as CreateFTPServer, using ctx for cancellation and deadlines
+func (c Client) CreateFTPServerWithContext(ctx context.Context, t FTPServer) (*FTPServer, error) {
+	resp, err := c.post(ctx, "/tests/ftp-server/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create ftp test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create ftp test")
 	}
 	var target map[string][]FTPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -118,24 +129,34 @@ func (c Client) CreateFTPServer(t FTPServer) (*FTPServer, error) {
 
 // DeleteFTPServer - delete ftp server test
 func (c *Client) DeleteFTPServer(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/ftp-server/%d/delete", id), nil, nil)
+	return c.DeleteFTPServerWithContext(context.Background(), id)
+}
+
+// DeleteFTPServerWithContext - same as DeleteFTPServer, using ctx for cancellation and deadlines
+func (c *Client) DeleteFTPServerWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/ftp-server/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete ftp server test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete ftp server test")
 	}
 	return nil
 }
 
 // UpdateFTPServer - - Update ftp server test
 func (c *Client) UpdateFTPServer(id int64, t FTPServer) (*FTPServer, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/ftp-server/%d/update", id), t, nil)
+	return c.UpdateFTPServerWithContext(context.Background(), id, t)
+}
+
+// UpdateFTPServerWithContext - same as UpdateFTPServer, using ctx for cancellation and deadlines
+func (c *Client) UpdateFTPServerWithContext(ctx context.Context, id int64, t FTPServer) (*FTPServer, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/ftp-server/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update ftp test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update ftp test")
 	}
 	var target map[string][]FTPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -59,7 +60,12 @@ func (t *GroupLabel) UnmarshalJSON(data []byte) error {
 
 // GetGroupLabels - Get labels
 func (c *Client) GetGroupLabels() (*GroupLabels, error) {
-	resp, err := c.get("/groups")
+	return c.GetGroupLabelsWithContext(context.Background())
+}
+
+// GetGroupLabelsWithContext - same as GetGroupLabels, using ctx for cancellation and deadlines
+func (c *Client) GetGroupLabelsWithContext(ctx context.Context) (*GroupLabels, error) {
+	resp, err := c.get(ctx, "/groups")
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +79,12 @@ func (c *Client) GetGroupLabels() (*GroupLabels, error) {
 
 // GetGroupLabelsByType - Get label by type
 func (c *Client) GetGroupLabelsByType(t string) (*GroupLabels, error) {
-	resp, err := c.get("/groups/" + t)
+	return c.GetGroupLabelsByTypeWithContext(context.Background(), t)
+}
+
+// GetGroupLabelsByTypeWithContext - same as GetGroupLabelsByType, using ctx for cancellation and deadlines
+func (c *Client) GetGroupLabelsByTypeWithContext(ctx context.Context, t string) (*GroupLabels, error) {
+	resp, err := c.get(ctx, "/groups/"+t)
 	if err != nil {
 		return &GroupLabels{}, err
 	}
@@ -87,7 +98,12 @@ func (c *Client) GetGroupLabelsByType(t string) (*GroupLabels, error) {
 
 // GetGroupLabel - Get single group label by ID
 func (c *Client) GetGroupLabel(id int64) (*GroupLabel, error) {
-	resp, err := c.get(fmt.Sprintf("/groups/%d", id))
+	return c.GetGroupLabelWithContext(context.Background(), id)
+}
+
+// GetGroupLabelWithContext - same as GetGroupLabel, using ctx for cancellation and deadlines
+func (c *Client) GetGroupLabelWithContext(ctx context.Context, id int64) (*GroupLabel, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/groups/%d", id))
 	if err != nil {
 		return &GroupLabel{}, err
 	}
@@ -100,6 +116,11 @@ func (c *Client) GetGroupLabel(id int64) (*GroupLabel, error) {
 
 // CreateGroupLabel - Create label
 func (c Client) CreateGroupLabel(a GroupLabel) (*GroupLabel, error) {
+	return c.CreateGroupLabelWithContext(context.Background(), a)
+}
+
+// CreateGroupLabelWithContext - same as CreateGroupLabel, using ctx for cancellation and deadlines
+func (c Client) CreateGroupLabelWithContext(ctx context.Context, a GroupLabel) (*GroupLabel, error) {
 	if a.Type == nil {
 		a.Type = String("")
 	}
@@ -107,12 +128,12 @@ func (c Client) CreateGroupLabel(a GroupLabel) (*GroupLabel, error) {
 	// Now we must set Type to blank.  Because even though it's required to know the submit path,
 	// TE will return an error if we also submit it a part of the object.
 	a.Type = String("")
-	resp, err := c.post(path, a, nil)
+	resp, err := c.post(ctx, path, a, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to create label, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to create label")
 	}
 
 	var target map[string]GroupLabels
@@ -125,24 +146,34 @@ func (c Client) CreateGroupLabel(a GroupLabel) (*GroupLabel, error) {
 
 // DeleteGroupLabel - delete label
 func (c Client) DeleteGroupLabel(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/groups/%d/delete", id), nil, nil)
+	return c.DeleteGroupLabelWithContext(context.Background(), id)
+}
+
+// DeleteGroupLabelWithContext - same as DeleteGroupLabel, using ctx for cancellation and deadlines
+func (c Client) DeleteGroupLabelWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/groups/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete label, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete label")
 	}
 	return nil
 }
 
 // UpdateGroupLabel - update label
 func (c Client) UpdateGroupLabel(id int64, a GroupLabel) (*GroupLabels, error) {
-	resp, err := c.post(fmt.Sprintf("/groups/%d/update", id), a, nil)
+	return c.UpdateGroupLabelWithContext(context.Background(), id, a)
+}
+
+// UpdateGroupLabelWithContext - same as UpdateGroupLabel, using ctx for cancellation and deadlines
+func (c Client) UpdateGroupLabelWithContext(ctx context.Context, id int64, a GroupLabel) (*GroupLabels, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/groups/%d/update", id), a, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to update label, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update label")
 	}
 
 	var target map[string]GroupLabels
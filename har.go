@@ -0,0 +1,276 @@
+package thousandeyes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HAR - an HTTP Archive 1.2 document, as read by browser developer tools
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog - the root of a HAR document
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Pages   []HARPage  `json:"pages"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator - the application which created a HAR document
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HARPage - a page of a HAR document
+type HARPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     HARPageTimings `json:"pageTimings"`
+}
+
+// HARPageTimings - page load times in milliseconds, -1 when unknown
+type HARPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+// HAREntry - a request made while loading a page
+type HAREntry struct {
+	PageRef         string      `json:"pageref"`
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+}
+
+// HARRequest - the request of a HAR entry
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARResponse - the response of a HAR entry
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARContent - details of a response body
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+// HARNameValue - a header, cookie or query string parameter
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARTimings - the phases of a request in milliseconds.  Blocked, DNS,
+// Connect and SSL are -1 when unknown, while Send, Wait and Receive, which
+// the HAR format requires, are 0.  As the HAR format requires, Connect
+// includes SSL.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harTimeFormat is the ISO 8601 format of HAR dates
+const harTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// HAR - converts the page load detail into a HAR 1.2 document
+func (d PageLoadDetail) HAR() HAR {
+	start := d.startTime()
+	pageID := "page_1"
+	if d.RoundID != nil {
+		pageID = fmt.Sprintf("page_%d", *d.RoundID)
+	}
+	page := HARPage{
+		StartedDateTime: start.Format(harTimeFormat),
+		ID:              pageID,
+		Title:           stringValue(d.URL),
+		PageTimings: HARPageTimings{
+			OnContentLoad: harTime(d.DOMLoadTime),
+			OnLoad:        harTime(d.PageLoadTime),
+		},
+	}
+
+	entries := []HAREntry{}
+	if d.Components != nil {
+		for _, component := range *d.Components {
+			entries = append(entries, component.harEntry(pageID, start))
+		}
+	}
+	return HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "ThousandEyes Go SDK", Version: "2"},
+		Pages:   []HARPage{page},
+		Entries: entries,
+	}}
+}
+
+// startTime returns the start of the round, from Date or else RoundID
+func (d PageLoadDetail) startTime() time.Time {
+	if d.Date != nil {
+		if t, err := time.Parse("2006-01-02 15:04:05", *d.Date); err == nil {
+			return t
+		}
+	}
+	if d.RoundID != nil {
+		return time.Unix(*d.RoundID, 0).UTC()
+	}
+	return time.Unix(0, 0).UTC()
+}
+
+func (p PageLoadComponent) harEntry(pageID string, pageStart time.Time) HAREntry {
+	started := pageStart
+	if p.StartTime != nil {
+		started = started.Add(time.Duration(*p.StartTime * float64(time.Millisecond)))
+	}
+	timings := HARTimings{
+		Blocked: harTime(p.BlockedTime),
+		DNS:     harTime(p.DNSTime),
+		Connect: harTime(p.ConnectTime),
+		Send:    harRequiredTime(p.SendTime),
+		Wait:    harRequiredTime(p.WaitTime),
+		Receive: harRequiredTime(p.ReceiveTime),
+		SSL:     harTime(p.SSLTime),
+	}
+	if timings.SSL > 0 {
+		if timings.Connect < 0 {
+			timings.Connect = 0
+		}
+		timings.Connect += timings.SSL
+	}
+	total := harTime(p.TotalTime)
+	if total < 0 {
+		total = 0
+		for _, t := range []float64{timings.Blocked, timings.DNS, timings.Connect, timings.Send, timings.Wait, timings.Receive} {
+			if t > 0 {
+				total += t
+			}
+		}
+	}
+
+	method := stringValue(p.Method)
+	if method == "" {
+		method = "GET"
+	}
+	httpVersion := stringValue(p.HTTPVersion)
+	contentSize := harSize(p.BodySize)
+	if contentSize < 0 {
+		contentSize = 0
+	}
+	// The body size on the wire excludes the headers
+	bodySize := int64(-1)
+	if p.WireSize != nil && p.HeadersSize != nil {
+		bodySize = *p.WireSize - *p.HeadersSize
+	}
+	status := 0
+	if p.ResponseCode != nil {
+		status = *p.ResponseCode
+	}
+	return HAREntry{
+		PageRef:         pageID,
+		StartedDateTime: started.Format(harTimeFormat),
+		Time:            total,
+		Request: HARRequest{
+			Method:      method,
+			URL:         stringValue(p.URL),
+			HTTPVersion: httpVersion,
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			QueryString: harQueryString(stringValue(p.URL)),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Response: HARResponse{
+			Status:      status,
+			StatusText:  http.StatusText(status),
+			HTTPVersion: httpVersion,
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			Content: HARContent{
+				Size:     contentSize,
+				MimeType: stringValue(p.MimeType),
+			},
+			HeadersSize: harSize(p.HeadersSize),
+			BodySize:    bodySize,
+		},
+		Timings:         timings,
+		ServerIPAddress: stringValue(p.ServerIP),
+	}
+}
+
+// harTime returns a HAR time, -1 for unknown ones
+func harTime(v *float64) float64 {
+	if v == nil {
+		return -1
+	}
+	return *v
+}
+
+// harRequiredTime returns a HAR time which may not be unknown, 0 for
+// unknown ones
+func harRequiredTime(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// harSize returns a HAR size, -1 for unknown ones
+func harSize(v *int64) int64 {
+	if v == nil {
+		return -1
+	}
+	return *v
+}
+
+// harQueryString returns the query string parameters of rawURL, in order
+func harQueryString(rawURL string) []HARNameValue {
+	params := []HARNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return params
+	}
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		kv := strings.SplitN(pair, "=", 2)
+		name, _ := url.QueryUnescape(kv[0])
+		var value string
+		if len(kv) == 2 {
+			value, _ = url.QueryUnescape(kv[1])
+		}
+		params = append(params, HARNameValue{Name: name, Value: value})
+	}
+	return params
+}
@@ -0,0 +1,78 @@
+package thousandeyes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageLoadDetail_HAR(t *testing.T) {
+	detail := PageLoadDetail{
+		Date:         String("2022-03-01 10:00:00"),
+		RoundID:      Int64(1646128800),
+		URL:          String("https://example.com/"),
+		DOMLoadTime:  Float64(800),
+		PageLoadTime: Float64(1500),
+		Components: &[]PageLoadComponent{
+			{
+				URL:          String("https://example.com/app.js?v=2&lang=en%20US"),
+				HTTPVersion:  String("HTTP/2"),
+				ResponseCode: Int(200),
+				ServerIP:     String("192.0.2.1"),
+				MimeType:     String("application/javascript"),
+				StartTime:    Float64(250.5),
+				DNSTime:      Float64(5),
+				ConnectTime:  Float64(10),
+				SSLTime:      Float64(20),
+				WaitTime:     Float64(30),
+				ReceiveTime:  Float64(2),
+				WireSize:     Int64(4096),
+				HeadersSize:  Int64(96),
+			},
+			{
+				URL:     String("https://example.com/style.css"),
+				SSLTime: Float64(15),
+			},
+		},
+	}
+
+	har := detail.HAR()
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, []HARPage{{
+		StartedDateTime: "2022-03-01T10:00:00.000Z",
+		ID:              "page_1646128800",
+		Title:           "https://example.com/",
+		PageTimings:     HARPageTimings{OnContentLoad: 800, OnLoad: 1500},
+	}}, har.Log.Pages)
+
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "page_1646128800", entry.PageRef)
+	assert.Equal(t, "2022-03-01T10:00:00.250Z", entry.StartedDateTime)
+	assert.Equal(t, float64(67), entry.Time)
+	assert.Equal(t, "GET", entry.Request.Method)
+	assert.Equal(t, []HARNameValue{{Name: "v", Value: "2"}, {Name: "lang", Value: "en US"}}, entry.Request.QueryString)
+	assert.Equal(t, "OK", entry.Response.StatusText)
+	assert.Equal(t, HARContent{Size: 0, MimeType: "application/javascript"}, entry.Response.Content)
+	assert.Equal(t, int64(4000), entry.Response.BodySize)
+	assert.Equal(t, HARTimings{Blocked: -1, DNS: 5, Connect: 30, Send: 0, Wait: 30, Receive: 2, SSL: 20}, entry.Timings)
+	assert.Equal(t, "192.0.2.1", entry.ServerIPAddress)
+
+	// Connect includes SSL even when the connect time is unknown
+	entry = har.Log.Entries[1]
+	assert.Equal(t, HARTimings{Blocked: -1, DNS: -1, Connect: 15, Send: 0, Wait: 0, Receive: 0, SSL: 15}, entry.Timings)
+	assert.Equal(t, int64(-1), entry.Response.BodySize)
+
+	data, err := json.Marshal(har)
+	assert.Nil(t, err)
+	var doc map[string]map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "ThousandEyes Go SDK", doc["log"]["creator"].(map[string]interface{})["name"])
+}
+
+func TestPageLoadDetail_HARWithoutDate(t *testing.T) {
+	har := PageLoadDetail{RoundID: Int64(1646128800)}.HAR()
+	assert.Equal(t, "2022-03-01T10:00:00.000Z", har.Log.Pages[0].StartedDateTime)
+	assert.Equal(t, float64(-1), har.Log.Pages[0].PageTimings.OnLoad)
+	assert.Equal(t, []HAREntry{}, har.Log.Entries)
+}
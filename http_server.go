@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -110,7 +111,12 @@ func (t *HTTPServer) AddAgent(id int64) {
 
 // GetHTTPServer - Get an HTTP Server test
 func (c *Client) GetHTTPServer(id int64) (*HTTPServer, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetHTTPServerWithContext(context.Background(), id)
+}
+
+// GetHTTPServerWithContext - same as GetHTTPServer, using ctx for cancellation and deadlines
+func (c *Client) GetHTTPServerWithContext(ctx context.Context, id int64) (*HTTPServer, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &HTTPServer{}, err
 	}
@@ -123,12 +129,17 @@ func (c *Client) GetHTTPServer(id int64) (*HTTPServer, error) {
 
 // CreateHTTPServer - create a http server
 func (c Client) CreateHTTPServer(t HTTPServer) (*HTTPServer, error) {
-	resp, err := c.post("/tests/http-server/new", t, nil)
+	return c.CreateHTTPServerWithContext(context.Background(), t)
+}
+
+// CreateHTTPServerWithContext - same as CreateHTTPServer, using ctx for cancellation and deadlines
+func (c Client) CreateHTTPServerWithContext(ctx context.Context, t HTTPServer) (*HTTPServer, error) {
+	resp, err := c.post(ctx, "/tests/http-server/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create http server, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create http server")
 	}
 	var target map[string][]HTTPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -139,24 +150,34 @@ func (c Client) CreateHTTPServer(t HTTPServer) (*HTTPServer, error) {
 
 // DeleteHTTPServer - delete an http server
 func (c *Client) DeleteHTTPServer(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/http-server/%d/delete", id), nil, nil)
+	return c.DeleteHTTPServerWithContext(context.Background(), id)
+}
+
+// DeleteHTTPServerWithContext - same as DeleteHTTPServer, using ctx for cancellation and deadlines
+func (c *Client) DeleteHTTPServerWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/http-server/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete http server, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete http server")
 	}
 	return nil
 }
 
 // UpdateHTTPServer - Update an http server test
 func (c *Client) UpdateHTTPServer(id int64, t HTTPServer) (*HTTPServer, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/http-server/%d/update", id), t, nil)
+	return c.UpdateHTTPServerWithContext(context.Background(), id, t)
+}
+
+// UpdateHTTPServerWithContext - same as UpdateHTTPServer, using ctx for cancellation and deadlines
+func (c *Client) UpdateHTTPServerWithContext(ctx context.Context, id int64, t HTTPServer) (*HTTPServer, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/http-server/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update http server, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update http server")
 	}
 	var target map[string][]HTTPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
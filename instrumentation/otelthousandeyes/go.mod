@@ -0,0 +1,32 @@
+module github.com/thousandeyes/thousandeyes-sdk-go/instrumentation/otelthousandeyes
+
+// go.opentelemetry.io/otel v1.46.0 requires Go 1.25, while the SDK module
+// itself still supports Go 1.17.
+go 1.25.0
+
+require (
+	github.com/stretchr/testify v1.12.1
+	github.com/thousandeyes/thousandeyes-sdk-go/v2 v2.0.0
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/metric v1.46.0
+	go.opentelemetry.io/otel/sdk v1.46.0
+	go.opentelemetry.io/otel/sdk/metric v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+// This module is only built within the repository, against the SDK next
+// to it: the SDK's middleware and call stats APIs it uses are not part of
+// a tagged release yet, so the version required above is a placeholder
+// which the replace directive overrides.  It must be raised to the first release
+// with those APIs before the module can be used outside the repository.
+replace github.com/thousandeyes/thousandeyes-sdk-go/v2 => ../../
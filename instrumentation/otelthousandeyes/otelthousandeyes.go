@@ -0,0 +1,160 @@
+// Package otelthousandeyes instruments the ThousandEyes SDK client with
+// OpenTelemetry.  It records a span per API call and metrics for call
+// latency, rate limiting and retries.
+//
+//	mw, err := otelthousandeyes.NewMiddleware()
+//	if err != nil {
+//		return err
+//	}
+//	client := thousandeyes.NewClient(&thousandeyes.ClientOptions{
+//		AuthToken:  token,
+//		Middleware: []thousandeyes.Middleware{mw},
+//	})
+package otelthousandeyes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter
+const ScopeName = "github.com/thousandeyes/thousandeyes-sdk-go/instrumentation/otelthousandeyes"
+
+// Attribute keys recorded on spans and metrics
+const (
+	MethodKey         = attribute.Key("http.request.method")
+	StatusCodeKey     = attribute.Key("http.response.status_code")
+	PathTemplateKey   = attribute.Key("thousandeyes.path_template")
+	AttemptsKey       = attribute.Key("thousandeyes.attempts")
+	RateLimitedKey    = attribute.Key("thousandeyes.rate_limited")
+	RateLimitSleepKey = attribute.Key("thousandeyes.rate_limit.sleep")
+	RetrySleepKey     = attribute.Key("thousandeyes.retry.sleep")
+)
+
+// Option - configures the instrumentation
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider, the global provider by default
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider, the global provider by default
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+type instruments struct {
+	tracer         trace.Tracer
+	duration       metric.Float64Histogram
+	requests       metric.Int64Counter
+	rateLimited    metric.Int64Counter
+	retries        metric.Int64Counter
+	rateLimitSleep metric.Float64Histogram
+}
+
+// NewMiddleware returns a Middleware recording a span and metrics for
+// every call made by the client
+func NewMiddleware(opts ...Option) (thousandeyes.Middleware, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+	inst := instruments{tracer: cfg.tracerProvider.Tracer(ScopeName)}
+	var err error
+	if inst.duration, err = meter.Float64Histogram("thousandeyes.client.call.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of API calls, including rate limit and retry sleeps")); err != nil {
+		return nil, err
+	}
+	if inst.requests, err = meter.Int64Counter("thousandeyes.client.requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("HTTP requests sent, including retries")); err != nil {
+		return nil, err
+	}
+	if inst.rateLimited, err = meter.Int64Counter("thousandeyes.client.rate_limited",
+		metric.WithUnit("{response}"),
+		metric.WithDescription("Responses rejected with HTTP 429")); err != nil {
+		return nil, err
+	}
+	if inst.retries, err = meter.Int64Counter("thousandeyes.client.retries",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Requests retried after a failed attempt")); err != nil {
+		return nil, err
+	}
+	if inst.rateLimitSleep, err = meter.Float64Histogram("thousandeyes.client.rate_limit.sleep",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time API calls slept for rate limiting")); err != nil {
+		return nil, err
+	}
+	return inst.middleware, nil
+}
+
+func (inst instruments) middleware(next thousandeyes.Handler) thousandeyes.Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		stats := thousandeyes.CallStatsFromContext(req.Context())
+		template := req.URL.Path
+		if stats != nil {
+			template = stats.PathTemplate
+		}
+
+		ctx, span := inst.tracer.Start(req.Context(), req.Method+" "+template,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(MethodKey.String(req.Method), PathTemplateKey.String(template)))
+		defer span.End()
+
+		resp, err := next(req.WithContext(ctx))
+
+		attrs := []attribute.KeyValue{MethodKey.String(req.Method), PathTemplateKey.String(template)}
+		if resp != nil {
+			attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		set := metric.WithAttributes(attrs...)
+		inst.duration.Record(ctx, time.Since(start).Seconds(), set)
+		span.SetAttributes(attrs...)
+
+		if stats != nil {
+			span.SetAttributes(
+				AttemptsKey.Int(stats.Attempts),
+				RateLimitedKey.Int(stats.RateLimited),
+				RateLimitSleepKey.Float64(stats.RateLimitDelay.Seconds()),
+				RetrySleepKey.Float64(stats.RetryDelay.Seconds()),
+			)
+			inst.requests.Add(ctx, int64(stats.Attempts), set)
+			if stats.Attempts > 1 {
+				inst.retries.Add(ctx, int64(stats.Attempts-1), set)
+			}
+			if stats.RateLimited > 0 {
+				inst.rateLimited.Add(ctx, int64(stats.RateLimited), set)
+			}
+			inst.rateLimitSleep.Record(ctx, stats.RateLimitDelay.Seconds(), set)
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,111 @@
+package otelthousandeyes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc) (*thousandeyes.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	mw, err := NewMiddleware(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	require.NoError(t, err)
+
+	client := thousandeyes.NewClient(&thousandeyes.ClientOptions{
+		APIEndpoint: server.URL,
+		Logger:      thousandeyes.NopLogger{},
+		Middleware:  []thousandeyes.Middleware{mw},
+	})
+	return client, spans, reader
+}
+
+func metricsByName(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func TestMiddleware(t *testing.T) {
+	attempts := 0
+	client, spans, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"test":[{"testId":122621}]}`))
+	})
+
+	_, err := client.GetHTTPServer(122621)
+	require.NoError(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	span := ended[0]
+	assert.Equal(t, "GET /tests/{id}", span.Name())
+	attrs := attribute.NewSet(span.Attributes()...)
+	v, _ := attrs.Value(PathTemplateKey)
+	assert.Equal(t, "/tests/{id}", v.AsString())
+	v, _ = attrs.Value(StatusCodeKey)
+	assert.Equal(t, int64(200), v.AsInt64())
+	v, _ = attrs.Value(AttemptsKey)
+	assert.Equal(t, int64(2), v.AsInt64())
+	v, _ = attrs.Value(RateLimitedKey)
+	assert.Equal(t, int64(1), v.AsInt64())
+
+	metrics := metricsByName(t, reader)
+	duration := metrics["thousandeyes.client.call.duration"].Data.(metricdata.Histogram[float64])
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	requests := metrics["thousandeyes.client.requests"].Data.(metricdata.Sum[int64])
+	assert.Equal(t, int64(2), requests.DataPoints[0].Value)
+	rateLimited := metrics["thousandeyes.client.rate_limited"].Data.(metricdata.Sum[int64])
+	assert.Equal(t, int64(1), rateLimited.DataPoints[0].Value)
+	retries := metrics["thousandeyes.client.retries"].Data.(metricdata.Sum[int64])
+	assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+}
+
+func TestMiddlewareError(t *testing.T) {
+	client, spans, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorMessage":"not found"}`))
+	})
+
+	err := client.DeleteHTTPServer(1)
+	assert.True(t, thousandeyes.IsNotFound(err))
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Equal(t, "POST /tests/http-server/{id}/delete", ended[0].Name())
+	assert.Equal(t, codes.Error, ended[0].Status().Code)
+
+	metrics := metricsByName(t, reader)
+	_, ok := metrics["thousandeyes.client.rate_limited"]
+	assert.False(t, ok)
+	duration := metrics["thousandeyes.client.call.duration"].Data.(metricdata.Histogram[float64])
+	status, _ := duration.DataPoints[0].Attributes.Value(StatusCodeKey)
+	assert.Equal(t, int64(404), status.AsInt64())
+}
@@ -1,6 +1,9 @@
 package thousandeyes
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Integration - Integration struct
 type Integration struct {
@@ -16,7 +19,12 @@ type Integration struct {
 
 // GetIntegrations - Get third party and webhook integrations
 func (c *Client) GetIntegrations() (*[]Integration, error) {
-	resp, err := c.get("/integrations")
+	return c.GetIntegrationsWithContext(context.Background())
+}
+
+// GetIntegrationsWithContext - same as GetIntegrations, using ctx for cancellation and deadlines
+func (c *Client) GetIntegrationsWithContext(ctx context.Context) (*[]Integration, error) {
+	resp, err := c.get(ctx, "/integrations")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,180 @@
+package thousandeyes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Logger - receives the SDK's diagnostic messages, such as rate limit
+// sleeps and retries.  keysAndValues holds alternating keys and values.
+// A *slog.Logger satisfies this interface.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// StdLogger - Logger writing through the standard library log package,
+// in the form "[INFO] message key=value".  Debug messages are discarded
+// unless Verbose is set.
+type StdLogger struct {
+	// Logger is the destination, the standard logger when nil
+	Logger  *log.Logger
+	Verbose bool
+}
+
+// Debug - Satisfying the Logger interface
+func (l StdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	if l.Verbose {
+		l.print("DEBUG", msg, keysAndValues)
+	}
+}
+
+// Info - Satisfying the Logger interface
+func (l StdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.print("INFO", msg, keysAndValues)
+}
+
+// Error - Satisfying the Logger interface
+func (l StdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.print("ERROR", msg, keysAndValues)
+}
+
+func (l StdLogger) print(level, msg string, keysAndValues []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", keysAndValues[i])
+		}
+	}
+	if l.Logger != nil {
+		l.Logger.Print(b.String())
+	} else {
+		log.Print(b.String())
+	}
+}
+
+// NopLogger - Logger discarding every message
+type NopLogger struct{}
+
+// Debug - Satisfying the Logger interface
+func (NopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+
+// Info - Satisfying the Logger interface
+func (NopLogger) Info(msg string, keysAndValues ...interface{}) {}
+
+// Error - Satisfying the Logger interface
+func (NopLogger) Error(msg string, keysAndValues ...interface{}) {}
+
+// logger returns the client's Logger, defaulting to a StdLogger
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return StdLogger{}
+	}
+	return c.Logger
+}
+
+// redactedFields lists the JSON keys whose values are not logged
+var redactedFields = map[string]bool{
+	"authToken": true,
+	"password":  true,
+	"token":     true,
+}
+
+// redactedHeaders lists the headers whose values are not logged, whether
+// they appear as "Name: value" strings, such as the headers of HTTP server
+// tests, or as keys, such as in CustomHeaders
+var redactedHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+	"x-auth-token":        true,
+}
+
+// bodyLoggingMiddleware logs request and response bodies at debug level,
+// redacting credentials.  The bodies of error responses are logged too, as
+// checkResponse leaves them readable.
+func (c *Client) bodyLoggingMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				c.logger().Debug("API request", "method", req.Method, "path", req.URL.Path, "body", redact(data))
+			}
+		} else {
+			c.logger().Debug("API request", "method", req.Method, "path", req.URL.Path)
+		}
+
+		resp, err := next(req)
+		if resp == nil || resp.Body == nil {
+			c.logger().Debug("API call failed", "method", req.Method, "path", req.URL.Path, "error", err)
+			return resp, err
+		}
+		data, rErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if err != nil {
+			c.logger().Debug("API call failed", "method", req.Method, "path", req.URL.Path,
+				"status", resp.StatusCode, "body", redact(data), "error", err)
+			return resp, err
+		}
+		if rErr != nil {
+			return resp, rErr
+		}
+		c.logger().Debug("API response", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "body", redact(data))
+		return resp, nil
+	}
+}
+
+// redact replaces the values of credential fields in a JSON document
+func redact(data []byte) string {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return string(data)
+	}
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return string(data)
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if redactedFields[k] || redactedHeaders[strings.ToLower(k)] {
+				v[k] = "REDACTED"
+			} else {
+				v[k] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	case string:
+		return redactHeader(v)
+	}
+	return v
+}
+
+// redactHeader replaces the value of a "Name: value" header string when
+// the header carries credentials
+func redactHeader(s string) string {
+	i := strings.Index(s, ":")
+	if i < 0 || !redactedHeaders[strings.ToLower(strings.TrimSpace(s[:i]))] {
+		return s
+	}
+	return s[:i+1] + " REDACTED"
+}
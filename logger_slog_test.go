@@ -0,0 +1,9 @@
+//go:build go1.21
+// +build go1.21
+
+package thousandeyes
+
+import "log/slog"
+
+// *slog.Logger can be used as a Logger without an adapter
+var _ Logger = (*slog.Logger)(nil)
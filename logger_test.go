@@ -0,0 +1,117 @@
+package thousandeyes
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues []interface{}) {
+	l.messages = append(l.messages, fmt.Sprint(append([]interface{}{level, msg}, keysAndValues...)...))
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record("debug ", msg, keysAndValues)
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record("info ", msg, keysAndValues)
+}
+
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.record("error ", msg, keysAndValues)
+}
+
+func TestClient_Logger(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+
+	logger := &recordingLogger{}
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, Logger: logger})
+	_, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Len(t, logger.messages, 1)
+	assert.True(t, strings.HasPrefix(logger.messages[0], "info Retrying request"))
+}
+
+func TestClient_LogBodies(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/tests/http-server/new.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"test":[{"testId":1,"testName":"test","password":"secret"}]}`))
+	})
+
+	logger := &recordingLogger{}
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, Logger: logger, LogBodies: true})
+	res, err := client.CreateHTTPServer(HTTPServer{TestName: String("test"), Password: String("secret")})
+	assert.Nil(t, err)
+	// The response body is still available to the caller
+	assert.Equal(t, "test", *res.TestName)
+	assert.Len(t, logger.messages, 2)
+	for _, m := range logger.messages {
+		assert.NotContains(t, m, "secret")
+		assert.Contains(t, m, "REDACTED")
+	}
+}
+
+func TestClient_LogBodiesError(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/tests/http-server/new.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessage":"invalid header"}`))
+	})
+
+	logger := &recordingLogger{}
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL, Logger: logger, LogBodies: true})
+	_, err := client.CreateHTTPServer(HTTPServer{Headers: &[]string{"Authorization: Bearer abc"}})
+	assert.True(t, IsBadRequest(err))
+	assert.Len(t, logger.messages, 2)
+	assert.Contains(t, logger.messages[0], "Authorization: REDACTED")
+	assert.NotContains(t, logger.messages[0], "abc")
+	assert.True(t, strings.HasPrefix(logger.messages[1], "debug API call failed"))
+	assert.Contains(t, logger.messages[1], "invalid header")
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := StdLogger{Logger: log.New(&buf, "", 0)}
+	l.Debug("hidden")
+	l.Info("Sleeping", "delay", "1s", "dangling")
+	l.Error("failed", "status", 500)
+	assert.Equal(t, "[INFO] Sleeping delay=1s dangling\n[ERROR] failed status=500\n", buf.String())
+
+	buf.Reset()
+	l.Verbose = true
+	l.Debug("shown", "id", 1)
+	assert.Equal(t, "[DEBUG] shown id=1\n", buf.String())
+}
+
+func Test_redact(t *testing.T) {
+	assert.Equal(t, `{"a":[{"authToken":"REDACTED"}],"password":"REDACTED","user":"u"}`,
+		redact([]byte(`{"user":"u","password":"p","a":[{"authToken":"t"}]}`)))
+	assert.Equal(t, "not json", redact([]byte("not json")))
+	assert.Equal(t, `{"headers":["authorization: REDACTED","Accept: */*","X-Api-Key: REDACTED"]}`,
+		redact([]byte(`{"headers":["authorization: Bearer abc","Accept: */*","X-Api-Key:xyz"]}`)))
+	assert.Equal(t, `{"customHeaders":{"root":{"Accept":"*/*","Authorization":"REDACTED"}}}`,
+		redact([]byte(`{"customHeaders":{"root":{"Authorization":"Bearer abc","Accept":"*/*"}}}`)))
+}
@@ -0,0 +1,138 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// Handler - performs an API request, returning the response or the error
+// which the Client method will return
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware - wraps a Handler to layer behaviour such as logging,
+// metrics or custom headers around every API call.  Middleware sees each
+// call once, however many attempts the RetryPolicy makes.
+type Middleware func(next Handler) Handler
+
+// CallStats - details of an API call gathered while it is performed.
+// Middleware can retrieve them with CallStatsFromContext, and they are
+// complete once the next Handler returns.
+type CallStats struct {
+	// Path is the API path, without the endpoint or format suffix
+	Path string
+	// PathTemplate is Path with identifiers replaced, e.g. /tests/{id}
+	PathTemplate string
+	// Attempts is the number of requests sent, including retries
+	Attempts int
+	// RateLimited counts responses rejected by rate limiting
+	RateLimited int
+	// RateLimitDelay is the total time slept for rate limiting
+	RateLimitDelay time.Duration
+	// RetryDelay is the total time slept backing off other failures
+	RetryDelay time.Duration
+}
+
+type callStatsKey struct{}
+
+// CallStatsFromContext returns the CallStats of the API call performed
+// with ctx, or nil
+func CallStatsFromContext(ctx context.Context) *CallStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*CallStats)
+	return stats
+}
+
+// pathIdentifier matches numeric path segments
+var pathIdentifier = regexp.MustCompile(`/[0-9]+(/|$)`)
+
+// PathTemplate replaces the numeric identifiers in an API path, so that
+// calls to the same endpoint can be grouped, e.g. /tests/123/delete
+// becomes /tests/{id}/delete
+func PathTemplate(path string) string {
+	// Replace twice, as adjacent identifiers share a slash
+	for i := 0; i < 2; i++ {
+		path = pathIdentifier.ReplaceAllString(path, "/{id}$1")
+	}
+	return path
+}
+
+// BeforeRequest returns a Middleware calling fn before the request is
+// sent.  The call is aborted with the error returned by fn, if any.
+func BeforeRequest(fn func(req *http.Request) error) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// AfterResponse returns a Middleware calling fn whenever a response is
+// received, including responses reported as an APIError.  An error
+// returned by fn is returned by the call if it had not failed already.
+func AfterResponse(fn func(req *http.Request, resp *http.Response) error) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if resp != nil {
+				if hErr := fn(req, resp); hErr != nil && err == nil {
+					err = hErr
+				}
+			}
+			return resp, err
+		}
+	}
+}
+
+// OnError returns a Middleware calling fn whenever a call fails
+func OnError(fn func(req *http.Request, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				fn(req, err)
+			}
+			return resp, err
+		}
+	}
+}
+
+// accountGroupMiddleware scopes requests to the client's account group
+func (c *Client) accountGroupMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.AccountGroupID != "" {
+			q := req.URL.Query()
+			q.Set("aid", c.AccountGroupID)
+			req.URL.RawQuery = q.Encode()
+		}
+		return next(req)
+	}
+}
+
+// defaultHeadersMiddleware sets the content negotiation, authorization and
+// user-agent headers
+func (c *Client) defaultHeadersMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("accept", "application/json")
+		req.Header.Set("authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+		req.Header.Set("content-type", "application/json")
+		req.Header.Set("user-agent", c.UserAgent)
+		return next(req)
+	}
+}
+
+// headersMiddleware sets the headers given for a single call
+func headersMiddleware(headers map[string]string) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			return next(req)
+		}
+	}
+}
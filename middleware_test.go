@@ -0,0 +1,155 @@
+package thousandeyes
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc", r.Header.Get("X-Request-ID"))
+		assert.Equal(t, "custom", r.Header.Get("user-agent"))
+		assert.Equal(t, "Bearer foo", r.Header.Get("authorization"))
+		assert.Equal(t, "bar", r.URL.Query().Get("aid"))
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+
+	var order []string
+	var status int
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		AuthToken:   "foo",
+		AccountID:   "bar",
+		Middleware: []Middleware{
+			BeforeRequest(func(req *http.Request) error {
+				order = append(order, "first")
+				req.Header.Set("X-Request-ID", "abc")
+				return nil
+			}),
+			BeforeRequest(func(req *http.Request) error {
+				order = append(order, "second")
+				// Default headers can be overridden
+				req.Header.Set("user-agent", "custom")
+				return nil
+			}),
+			AfterResponse(func(req *http.Request, resp *http.Response) error {
+				status = resp.StatusCode
+				return nil
+			}),
+		},
+	})
+	_, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, 200, status)
+}
+
+func TestClient_MiddlewareBody(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/alert-rules/new.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"ruleName":"test"}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"alertRuleId":1}`))
+	})
+
+	var audited string
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		Middleware: []Middleware{
+			BeforeRequest(func(req *http.Request) error {
+				// Reading the body does not prevent it being sent
+				body, _ := ioutil.ReadAll(req.Body)
+				audited = string(body)
+				return nil
+			}),
+		},
+	})
+	_, err := client.CreateAlertRule(AlertRule{RuleName: String("test")})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"ruleName":"test"}`, audited)
+}
+
+func TestClient_MiddlewareErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var hookErr error
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		Middleware: []Middleware{
+			OnError(func(req *http.Request, err error) {
+				hookErr = err
+			}),
+		},
+	})
+	_, err := client.GetAgents()
+	assert.True(t, IsNotFound(hookErr))
+	assert.Equal(t, err, hookErr)
+
+	// A failing BeforeRequest hook aborts the call
+	abort := errors.New("abort")
+	client.Middleware = append(client.Middleware, BeforeRequest(func(req *http.Request) error {
+		return abort
+	}))
+	_, err = client.GetAgents()
+	assert.Equal(t, abort, err)
+	assert.Equal(t, abort, hookErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_MiddlewareCallStats(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/tests/http-server/1/delete.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var stats *CallStats
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{503}},
+		Middleware: []Middleware{
+			func(next Handler) Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					resp, err := next(req)
+					stats = CallStatsFromContext(req.Context())
+					return resp, err
+				}
+			},
+		},
+	})
+	err := client.DeleteHTTPServer(1)
+	assert.Nil(t, err)
+	assert.Equal(t, &CallStats{
+		Path:         "/tests/http-server/1/delete",
+		PathTemplate: "/tests/http-server/{id}/delete",
+		Attempts:     3,
+	}, stats)
+}
+
+func TestPathTemplate(t *testing.T) {
+	assert.Equal(t, "/tests/{id}", PathTemplate("/tests/123"))
+	assert.Equal(t, "/tests/http-server/{id}/update", PathTemplate("/tests/http-server/123/update"))
+	assert.Equal(t, "/agents/{id}/{id}", PathTemplate("/agents/1/2"))
+	assert.Equal(t, "/groups/tests", PathTemplate("/groups/tests"))
+	assert.Equal(t, "/alert-rules", PathTemplate("/alert-rules"))
+}
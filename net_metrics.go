@@ -0,0 +1,69 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NetMetrics - end-to-end network metrics of a test
+type NetMetrics struct {
+	Test    GenericTest
+	Metrics []NetMetric
+}
+
+// NetMetric - end-to-end network metrics measured by an agent in a round
+type NetMetric struct {
+	AgentID      *int64               `json:"agentId,omitempty"`
+	AgentName    *string              `json:"agentName,omitempty"`
+	CountryID    *string              `json:"countryId,omitempty"`
+	Date         *string              `json:"date,omitempty"`
+	RoundID      *int64               `json:"roundId,omitempty"`
+	Server       *string              `json:"server,omitempty"`
+	ServerIP     *string              `json:"serverIp,omitempty"`
+	Loss         *float64             `json:"loss,omitempty"`
+	MinLatency   *float64             `json:"minLatency,omitempty"`
+	AvgLatency   *float64             `json:"avgLatency,omitempty"`
+	MaxLatency   *float64             `json:"maxLatency,omitempty"`
+	Jitter       *float64             `json:"jitter,omitempty"`
+	Permalink    *string              `json:"permalink,omitempty"`
+	ErrorDetails *[]AgentErrorDetails `json:"errorDetails,omitempty"`
+}
+
+// Agent - returns the agent which measured the metrics
+func (m NetMetric) Agent() Agent {
+	return Agent{AgentID: m.AgentID, AgentName: m.AgentName, CountryID: m.CountryID}
+}
+
+// GetNetMetrics - Get end-to-end network metrics of a test
+func (c *Client) GetNetMetrics(testID int64, opts *ResultsOptions) (*NetMetrics, error) {
+	return c.GetNetMetricsWithContext(context.Background(), testID, opts)
+}
+
+// GetNetMetricsWithContext - same as GetNetMetrics, using ctx for cancellation and deadlines
+func (c *Client) GetNetMetricsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*NetMetrics, error) {
+	results := NetMetrics{Metrics: []NetMetric{}}
+	err := c.getResults(ctx, fmt.Sprintf("/net/metrics/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Net struct {
+				Test    GenericTest `json:"test"`
+				Metrics []NetMetric `json:"metrics"`
+			} `json:"net"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Net.Test
+		for _, m := range target.Net.Metrics {
+			if opts.includes(m.AgentID, m.RoundID) {
+				results.Metrics = append(results.Metrics, m)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,62 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetNetMetrics(t *testing.T) {
+	out := `{"net": {"test": {"testId": 1, "testName": "example", "type": "agent-to-server"},
+	"metrics": [
+		{"agentId": 10, "agentName": "Dallas", "countryId": "US", "date": "2022-03-01 10:00:00", "roundId": 1646128800,
+		 "server": "example.com:443", "serverIp": "192.0.2.1", "loss": 0.0, "minLatency": 10, "avgLatency": 12.5,
+		 "maxLatency": 15, "jitter": 1.5, "permalink": "https://app.thousandeyes.com/x"},
+		{"agentId": 20, "agentName": "London", "roundId": 1646128800, "loss": 100.0,
+		 "errorDetails": [{"code": "TIMEOUT", "description": "Timeout"}]},
+		{"agentId": 10, "agentName": "Dallas", "roundId": 1646128500, "loss": 2.5}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "1h", r.URL.Query().Get("window"))
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetNetMetrics(1, &ResultsOptions{Window: "1h", AgentIDs: []int64{10}})
+	assert.Nil(t, err)
+	assert.Equal(t, Int64(1), res.Test.TestID)
+	expected := []NetMetric{
+		{
+			AgentID:    Int64(10),
+			AgentName:  String("Dallas"),
+			CountryID:  String("US"),
+			Date:       String("2022-03-01 10:00:00"),
+			RoundID:    Int64(1646128800),
+			Server:     String("example.com:443"),
+			ServerIP:   String("192.0.2.1"),
+			Loss:       Float64(0),
+			MinLatency: Float64(10),
+			AvgLatency: Float64(12.5),
+			MaxLatency: Float64(15),
+			Jitter:     Float64(1.5),
+			Permalink:  String("https://app.thousandeyes.com/x"),
+		},
+		{
+			AgentID:   Int64(10),
+			AgentName: String("Dallas"),
+			RoundID:   Int64(1646128500),
+			Loss:      Float64(2.5),
+		},
+	}
+	assert.Equal(t, expected, res.Metrics)
+	assert.Equal(t, Agent{AgentID: Int64(10), AgentName: String("Dallas"), CountryID: String("US")}, res.Metrics[0].Agent())
+
+	res, err = client.GetNetMetrics(1, &ResultsOptions{Window: "1h", AfterRoundID: 1646128500})
+	assert.Nil(t, err)
+	assert.Len(t, res.Metrics, 2)
+	assert.Equal(t, "TIMEOUT", *(*res.Metrics[1].ErrorDetails)[0].Code)
+}
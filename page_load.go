@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -97,7 +98,12 @@ func (t *PageLoad) AddAgent(id int64) {
 
 // GetPageLoad - get page load test
 func (c *Client) GetPageLoad(id int64) (*PageLoad, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetPageLoadWithContext(context.Background(), id)
+}
+
+// GetPageLoadWithContext - same as GetPageLoad, using ctx for cancellation and deadlines
+func (c *Client) GetPageLoadWithContext(ctx context.Context, id int64) (*PageLoad, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &PageLoad{}, err
 	}
@@ -110,12 +116,17 @@ func (c *Client) GetPageLoad(id int64) (*PageLoad, error) {
 
 // CreatePageLoad - create pager load test
 func (c Client) CreatePageLoad(t PageLoad) (*PageLoad, error) {
-	resp, err := c.post("/tests/page-load/new", t, nil)
+	return c.CreatePageLoadWithContext(context.Background(), t)
+}
+
+// CreatePageLoadWithContext - same as CreatePageLoad, using ctx for cancellation and deadlines
+func (c Client) CreatePageLoadWithContext(ctx context.Context, t PageLoad) (*PageLoad, error) {
+	resp, err := c.post(ctx, "/tests/page-load/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create test")
 	}
 	var target map[string][]PageLoad
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -126,24 +137,34 @@ func (c Client) CreatePageLoad(t PageLoad) (*PageLoad, error) {
 
 // DeletePageLoad - Delete page load tes
 func (c *Client) DeletePageLoad(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/page-load/%d/delete", id), nil, nil)
+	return c.DeletePageLoadWithContext(context.Background(), id)
+}
+
+// DeletePageLoadWithContext - same as DeletePageLoad, using ctx for cancellation and deadlines
+func (c *Client) DeletePageLoadWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/page-load/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete page load, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete page load")
 	}
 	return nil
 }
 
 // UpdatePageLoad - Upload page load
 func (c *Client) UpdatePageLoad(id int64, t PageLoad) (*PageLoad, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/page-load/%d/update", id), t, nil)
+	return c.UpdatePageLoadWithContext(context.Background(), id, t)
+}
+
+// UpdatePageLoadWithContext - same as UpdatePageLoad, using ctx for cancellation and deadlines
+func (c *Client) UpdatePageLoadWithContext(ctx context.Context, id int64, t PageLoad) (*PageLoad, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/page-load/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]PageLoad
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
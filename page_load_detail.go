@@ -0,0 +1,72 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageLoadDetail - the components loaded by an agent in a page load round
+type PageLoadDetail struct {
+	AgentID      *int64               `json:"agentId,omitempty"`
+	AgentName    *string              `json:"agentName,omitempty"`
+	CountryID    *string              `json:"countryId,omitempty"`
+	Date         *string              `json:"date,omitempty"`
+	RoundID      *int64               `json:"roundId,omitempty"`
+	URL          *string              `json:"url,omitempty"`
+	DOMLoadTime  *float64             `json:"domLoadTime,omitempty"`
+	PageLoadTime *float64             `json:"pageLoadTime,omitempty"`
+	Components   *[]PageLoadComponent `json:"components,omitempty"`
+	Permalink    *string              `json:"permalink,omitempty"`
+}
+
+// PageLoadComponent - an object fetched while loading a page.  StartTime
+// is the offset from the start of the page load, and times are all in
+// milliseconds.  Sizes are in bytes.
+type PageLoadComponent struct {
+	URL          *string  `json:"url,omitempty"`
+	Method       *string  `json:"method,omitempty"`
+	HTTPVersion  *string  `json:"httpVersion,omitempty"`
+	ResponseCode *int     `json:"responseCode,omitempty"`
+	ServerIP     *string  `json:"serverIp,omitempty"`
+	MimeType     *string  `json:"mimeType,omitempty"`
+	StartTime    *float64 `json:"startTime,omitempty"`
+	BlockedTime  *float64 `json:"blockedTime,omitempty"`
+	DNSTime      *float64 `json:"dnsTime,omitempty"`
+	ConnectTime  *float64 `json:"connectTime,omitempty"`
+	SSLTime      *float64 `json:"sslTime,omitempty"`
+	SendTime     *float64 `json:"sendTime,omitempty"`
+	WaitTime     *float64 `json:"waitTime,omitempty"`
+	ReceiveTime  *float64 `json:"receiveTime,omitempty"`
+	TotalTime    *float64 `json:"totalTime,omitempty"`
+	WireSize     *int64   `json:"wireSize,omitempty"`
+	HeadersSize  *int64   `json:"headersSize,omitempty"`
+	BodySize     *int64   `json:"bodySize,omitempty"`
+	ErrorType    *string  `json:"errorType,omitempty"`
+}
+
+// GetPageLoadDetail - Get the components loaded by an agent in a round of
+// a page load test
+func (c *Client) GetPageLoadDetail(testID, agentID, roundID int64) (*PageLoadDetail, error) {
+	return c.GetPageLoadDetailWithContext(context.Background(), testID, agentID, roundID)
+}
+
+// GetPageLoadDetailWithContext - same as GetPageLoadDetail, using ctx for cancellation and deadlines
+func (c *Client) GetPageLoadDetailWithContext(ctx context.Context, testID, agentID, roundID int64) (*PageLoadDetail, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/web/page-load/%d/%d/%d", testID, agentID, roundID))
+	if err != nil {
+		return nil, err
+	}
+	var target struct {
+		Web struct {
+			PageLoad []PageLoadDetail `json:"pageLoad"`
+		} `json:"web"`
+	}
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+	if len(target.Web.PageLoad) == 0 {
+		return nil, fmt.Errorf("no page load detail for agent %d in round %d", agentID, roundID)
+	}
+	detail := target.Web.PageLoad[0]
+	return &detail, nil
+}
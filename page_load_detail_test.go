@@ -0,0 +1,59 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetPageLoadDetail(t *testing.T) {
+	out := `{"web": {"test": {"testId": 1}, "pageLoad": [{"agentId": 10, "agentName": "Dallas",
+		"date": "2022-03-01 10:00:00", "roundId": 1646128800, "url": "https://example.com/",
+		"domLoadTime": 800, "pageLoadTime": 1500, "components": [
+			{"url": "https://example.com/", "method": "GET", "httpVersion": "HTTP/2", "responseCode": 200,
+			 "serverIp": "192.0.2.1", "mimeType": "text/html", "startTime": 0, "dnsTime": 5, "connectTime": 10,
+			 "sslTime": 20, "sendTime": 1, "waitTime": 30, "receiveTime": 2, "wireSize": 4096, "bodySize": 16384}
+		]}]}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/page-load/1/10/1646128800.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetPageLoadDetail(1, 10, 1646128800)
+	assert.Nil(t, err)
+	assert.Equal(t, Int64(10), res.AgentID)
+	assert.Equal(t, Float64(1500), res.PageLoadTime)
+	assert.Equal(t, PageLoadComponent{
+		URL:          String("https://example.com/"),
+		Method:       String("GET"),
+		HTTPVersion:  String("HTTP/2"),
+		ResponseCode: Int(200),
+		ServerIP:     String("192.0.2.1"),
+		MimeType:     String("text/html"),
+		StartTime:    Float64(0),
+		DNSTime:      Float64(5),
+		ConnectTime:  Float64(10),
+		SSLTime:      Float64(20),
+		SendTime:     Float64(1),
+		WaitTime:     Float64(30),
+		ReceiveTime:  Float64(2),
+		WireSize:     Int64(4096),
+		BodySize:     Int64(16384),
+	}, (*res.Components)[0])
+}
+
+func TestClient_GetPageLoadDetailEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/page-load/1/10/100.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"web": {"pageLoad": []}}`))
+	})
+
+	_, err := client.GetPageLoadDetail(1, 10, 100)
+	assert.EqualError(t, err, "no page load detail for agent 10 in round 100")
+}
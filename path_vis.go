@@ -0,0 +1,126 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// PathVis - path visualization results of a test
+type PathVis struct {
+	Test  GenericTest
+	Paths []PathTrace
+}
+
+// PathTrace - routes traced by an agent to the target in a round
+type PathTrace struct {
+	AgentID      *int64       `json:"agentId,omitempty"`
+	AgentName    *string      `json:"agentName,omitempty"`
+	CountryID    *string      `json:"countryId,omitempty"`
+	Date         *string      `json:"date,omitempty"`
+	RoundID      *int64       `json:"roundId,omitempty"`
+	Server       *string      `json:"server,omitempty"`
+	ServerIP     *string      `json:"serverIp,omitempty"`
+	SourceIP     *string      `json:"sourceIp,omitempty"`
+	SourcePrefix *string      `json:"sourcePrefix,omitempty"`
+	Routes       *[]PathRoute `json:"endpoints,omitempty"`
+	Permalink    *string      `json:"permalink,omitempty"`
+}
+
+// PathRoute - a route traced to one of the target's addresses
+type PathRoute struct {
+	PathID       *string    `json:"pathId,omitempty"`
+	IPAddress    *string    `json:"ipAddress,omitempty"`
+	ResponseTime *float64   `json:"responseTime,omitempty"`
+	NumberOfHops *int       `json:"numberOfHops,omitempty"`
+	Hops         *[]PathHop `json:"hops,omitempty"`
+}
+
+// PathHop - a hop of a route.  ResponseTime is the round trip delay in
+// milliseconds from the agent to the hop.
+type PathHop struct {
+	Hop          *int      `json:"hop,omitempty"`
+	IPAddress    *string   `json:"ipAddress,omitempty"`
+	Prefix       *string   `json:"prefix,omitempty"`
+	Rdns         *string   `json:"rdns,omitempty"`
+	Network      *string   `json:"network,omitempty"`
+	Location     *string   `json:"location,omitempty"`
+	ResponseTime *float64  `json:"responseTime,omitempty"`
+	MPLSLabels   *[]string `json:"mpls,omitempty"`
+}
+
+// Agent - returns the agent which traced the path
+func (p PathTrace) Agent() Agent {
+	return Agent{AgentID: p.AgentID, AgentName: p.AgentName, CountryID: p.CountryID}
+}
+
+// WorstHop - returns the hop adding the most delay to the route, along
+// with the delay it added over the previous responding hop, in
+// milliseconds.  Hops which did not respond are skipped.  It returns nil
+// when no hop responded.
+func (r PathRoute) WorstHop() (*PathHop, float64) {
+	if r.Hops == nil {
+		return nil, 0
+	}
+	var worst *PathHop
+	var worstDelay, previous float64
+	for i := range *r.Hops {
+		hop := &(*r.Hops)[i]
+		if hop.ResponseTime == nil {
+			continue
+		}
+		delay := *hop.ResponseTime - previous
+		if worst == nil || delay > worstDelay {
+			worst, worstDelay = hop, delay
+		}
+		previous = *hop.ResponseTime
+	}
+	return worst, worstDelay
+}
+
+// GetPathVis - Get path visualization results of a test
+func (c *Client) GetPathVis(testID int64, opts *ResultsOptions) (*PathVis, error) {
+	return c.GetPathVisWithContext(context.Background(), testID, opts)
+}
+
+// GetPathVisWithContext - same as GetPathVis, using ctx for cancellation and deadlines
+func (c *Client) GetPathVisWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*PathVis, error) {
+	return c.getPathVis(ctx, fmt.Sprintf("/net/path-vis/%d", testID), opts)
+}
+
+// GetPathVisDetail - Get the paths traced by an agent in a round
+func (c *Client) GetPathVisDetail(testID, agentID, roundID int64) (*PathVis, error) {
+	return c.GetPathVisDetailWithContext(context.Background(), testID, agentID, roundID)
+}
+
+// GetPathVisDetailWithContext - same as GetPathVisDetail, using ctx for cancellation and deadlines
+func (c *Client) GetPathVisDetailWithContext(ctx context.Context, testID, agentID, roundID int64) (*PathVis, error) {
+	return c.getPathVis(ctx, fmt.Sprintf("/net/path-vis/%d/%d/%d", testID, agentID, roundID), nil)
+}
+
+func (c *Client) getPathVis(ctx context.Context, path string, opts *ResultsOptions) (*PathVis, error) {
+	results := PathVis{Paths: []PathTrace{}}
+	err := c.getResults(ctx, path, opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Net struct {
+				Test    GenericTest `json:"test"`
+				PathVis []PathTrace `json:"pathVis"`
+			} `json:"net"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Net.Test
+		for _, p := range target.Net.PathVis {
+			if opts.includes(p.AgentID, p.RoundID) {
+				results.Paths = append(results.Paths, p)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,83 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pathVisOut = `{"net": {"test": {"testId": 1, "type": "agent-to-server"}, "pathVis": [
+	{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "example.com:443", "serverIp": "192.0.2.1",
+	 "sourcePrefix": "198.51.100.0/24", "endpoints": [
+		{"pathId": "p1", "ipAddress": "192.0.2.1", "responseTime": 40, "numberOfHops": 4, "hops": [
+			{"hop": 1, "ipAddress": "10.0.0.1", "prefix": "10.0.0.0/8", "responseTime": 1},
+			{"hop": 2, "ipAddress": "203.0.113.1", "rdns": "core1.example.net", "network": "Example (AS 64500)",
+			 "responseTime": 30, "mpls": ["Label 24001, Exp 0, S 1, TTL 1"]},
+			{"hop": 3},
+			{"hop": 4, "ipAddress": "192.0.2.1", "responseTime": 40}
+		]}
+	]},
+	{"agentId": 20, "agentName": "London", "roundId": 100, "endpoints": []}
+]}, "pages": {"current": 1}}`
+
+func TestClient_GetPathVis(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/path-vis/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(pathVisOut))
+	})
+
+	res, err := client.GetPathVis(1, &ResultsOptions{AgentIDs: []int64{10}, RoundID: 100})
+	assert.Nil(t, err)
+	assert.Len(t, res.Paths, 1)
+	path := res.Paths[0]
+	assert.Equal(t, Agent{AgentID: Int64(10), AgentName: String("Dallas")}, path.Agent())
+	assert.Equal(t, String("198.51.100.0/24"), path.SourcePrefix)
+	route := (*path.Routes)[0]
+	assert.Equal(t, Int(4), route.NumberOfHops)
+	hop := (*route.Hops)[1]
+	assert.Equal(t, PathHop{
+		Hop:          Int(2),
+		IPAddress:    String("203.0.113.1"),
+		Rdns:         String("core1.example.net"),
+		Network:      String("Example (AS 64500)"),
+		ResponseTime: Float64(30),
+		MPLSLabels:   &[]string{"Label 24001, Exp 0, S 1, TTL 1"},
+	}, hop)
+}
+
+func TestClient_GetPathVisDetail(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/path-vis/1/10/100.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(pathVisOut))
+	})
+
+	res, err := client.GetPathVisDetail(1, 10, 100)
+	assert.Nil(t, err)
+	assert.Len(t, res.Paths, 2)
+}
+
+func TestPathRoute_WorstHop(t *testing.T) {
+	route := PathRoute{Hops: &[]PathHop{
+		{Hop: Int(1), ResponseTime: Float64(1)},
+		{Hop: Int(2), ResponseTime: Float64(30)},
+		{Hop: Int(3)},
+		{Hop: Int(4), ResponseTime: Float64(40)},
+	}}
+	hop, delay := route.WorstHop()
+	assert.Equal(t, Int(2), hop.Hop)
+	assert.Equal(t, float64(29), delay)
+
+	hop, delay = PathRoute{}.WorstHop()
+	assert.Nil(t, hop)
+	assert.Equal(t, float64(0), delay)
+
+	hop, _ = PathRoute{Hops: &[]PathHop{{Hop: Int(1)}}}.WorstHop()
+	assert.Nil(t, hop)
+}
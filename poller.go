@@ -0,0 +1,393 @@
+package thousandeyes
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// defaultAgentTimeout is the Poller agent timeout when none is set
+const defaultAgentTimeout = time.Hour
+
+// PollResult - a result of an agent in a round, returned by a Poller
+type PollResult struct {
+	TestID  int64
+	AgentID int64
+	RoundID int64
+	// Result is the typed result, such as a NetMetric or an HTTPServerResult
+	Result interface{}
+}
+
+// ResultsFetcher - fetches the results of a test for a Poller.  The
+// Fetch functions of this package cover each type of results.
+type ResultsFetcher func(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error)
+
+// Checkpoint - the last round polled, keyed by test ID then agent ID
+type Checkpoint map[int64]map[int64]int64
+
+// CheckpointStore - persists the checkpoint of a Poller, so that polling
+// resumes where it stopped after a restart
+type CheckpointStore interface {
+	Load() (Checkpoint, error)
+	Save(Checkpoint) error
+}
+
+// FileCheckpointStore - CheckpointStore keeping the checkpoint in a JSON
+// file.  A missing file loads as an empty checkpoint.
+type FileCheckpointStore struct {
+	Path string
+}
+
+// Load - Satisfying the CheckpointStore interface
+func (s FileCheckpointStore) Load() (Checkpoint, error) {
+	checkpoint := Checkpoint{}
+	if err := loadJSONFile(s.Path, &checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Save - Satisfying the CheckpointStore interface
+func (s FileCheckpointStore) Save(checkpoint Checkpoint) error {
+	return saveJSONFile(s.Path, checkpoint)
+}
+
+// Poller - polls the results of tests round by round.  Each result is
+// returned once: the last round seen is tracked per test and agent, and
+// only rounds after it are requested.  Requests go through the client, so
+// its Limiter and RetryPolicy apply.  A Poller is not safe for concurrent
+// use.
+type Poller struct {
+	Client  *Client
+	TestIDs []int64
+	// Fetch fetches the results of a test, FetchNetMetrics by default
+	Fetch ResultsFetcher
+	// Interval is the time between polls, a minute by default
+	Interval time.Duration
+	// Window is requested for tests without a checkpoint, such as "1h".
+	// When empty, only their latest round is requested.
+	Window string
+	// Checkpoints, when set, persists the last rounds seen
+	Checkpoints CheckpointStore
+	// AgentTimeout is how far an agent's last round may fall behind the
+	// latest round of its test before the agent is dropped from the
+	// checkpoint, so that agents removed from a test do not hold polling
+	// back.  An hour by default.
+	AgentTimeout time.Duration
+
+	checkpoint Checkpoint
+}
+
+// Run - polls until ctx is done or handle returns an error, passing new
+// results to handle in round order.  Failing fetches are logged and
+// retried at the next poll.
+func (p *Poller) Run(ctx context.Context, handle func(PollResult) error) error {
+	return runEvery(ctx, p.Interval, func() error {
+		return p.Poll(ctx, handle)
+	})
+}
+
+// Stream - runs the poller in the background, sending new results to the
+// returned channel.  When polling stops, the error is sent to the error
+// channel and both channels are closed.
+func (p *Poller) Stream(ctx context.Context) (<-chan PollResult, <-chan error) {
+	results := make(chan PollResult)
+	errc := runInBackground(func() error {
+		return p.Run(ctx, func(r PollResult) error {
+			select {
+			case results <- r:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}, func() { close(results) })
+	return results, errc
+}
+
+// Poll - polls each test once, passing new results to handle in round
+// order.  It only returns an error when ctx is done, the checkpoint cannot
+// be loaded or saved, or handle fails.
+func (p *Poller) Poll(ctx context.Context, handle func(PollResult) error) error {
+	if p.checkpoint == nil {
+		p.checkpoint = Checkpoint{}
+		if p.Checkpoints != nil {
+			checkpoint, err := p.Checkpoints.Load()
+			if err != nil {
+				p.checkpoint = nil
+				return err
+			}
+			if checkpoint != nil {
+				p.checkpoint = checkpoint
+			}
+		}
+	}
+	fetch := p.Fetch
+	if fetch == nil {
+		fetch = FetchNetMetrics
+	}
+
+	for _, testID := range p.TestIDs {
+		results, err := fetch(ctx, p.Client, testID, p.options(testID))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			p.Client.logger().Error("Could not poll test results", "testId", testID, "error", err)
+			continue
+		}
+		if err := p.handle(testID, results, handle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// options returns the results options selecting the rounds of a test
+// after its checkpoint.  Agents whose last round is more than AgentTimeout
+// behind the latest one are dropped from the checkpoint first.
+func (p *Poller) options(testID int64) *ResultsOptions {
+	rounds := p.checkpoint[testID]
+	if len(rounds) == 0 {
+		return &ResultsOptions{Window: p.Window}
+	}
+	timeout := p.AgentTimeout
+	if timeout <= 0 {
+		timeout = defaultAgentTimeout
+	}
+	var latest int64
+	for _, round := range rounds {
+		if round > latest {
+			latest = round
+		}
+	}
+	// Round IDs are the epoch second the round started
+	earliest := latest
+	for agent, round := range rounds {
+		if round < latest-int64(timeout/time.Second) {
+			delete(rounds, agent)
+		} else if round < earliest {
+			earliest = round
+		}
+	}
+	return &ResultsOptions{From: time.Unix(earliest+1, 0)}
+}
+
+// handle passes the new results of a test to handle, then saves the
+// checkpoint.  All results of a round are passed, since some tests report
+// several results per agent and round.  When handle fails, the results of
+// the round are passed again at the next poll.
+func (p *Poller) handle(testID int64, results []PollResult, handle func(PollResult) error) error {
+	rounds := p.checkpoint[testID]
+	if rounds == nil {
+		rounds = map[int64]int64{}
+		p.checkpoint[testID] = rounds
+	}
+	fresh := []PollResult{}
+	for _, r := range results {
+		if r.RoundID > rounds[r.AgentID] {
+			fresh = append(fresh, r)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].RoundID < fresh[j].RoundID
+	})
+
+	last := map[int64]int64{}
+	for agent, round := range rounds {
+		last[agent] = round
+	}
+	var err error
+	for i := 0; i < len(fresh) && err == nil; {
+		// Rounds are checkpointed once all their results are handled
+		j := i
+		for ; j < len(fresh) && fresh[j].RoundID == fresh[i].RoundID; j++ {
+			if err = handle(fresh[j]); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			for _, r := range fresh[i:j] {
+				last[r.AgentID] = r.RoundID
+			}
+		}
+		i = j
+	}
+	p.checkpoint[testID] = last
+	if p.Checkpoints != nil {
+		if sErr := p.Checkpoints.Save(p.checkpoint); sErr != nil && err == nil {
+			err = sErr
+		}
+	}
+	return err
+}
+
+// pollResults builds the PollResults of a test from n results, at
+// returning the agent ID, round ID and value of the i-th one
+func pollResults(testID int64, n int, at func(i int) (agentID, roundID *int64, result interface{})) []PollResult {
+	polled := make([]PollResult, 0, n)
+	for i := 0; i < n; i++ {
+		agentID, roundID, result := at(i)
+		polled = append(polled, PollResult{TestID: testID, AgentID: int64Value(agentID), RoundID: int64Value(roundID), Result: result})
+	}
+	return polled
+}
+
+// FetchNetMetrics - ResultsFetcher for end-to-end network metrics
+func FetchNetMetrics(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetNetMetricsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Metrics), func(i int) (*int64, *int64, interface{}) {
+		r := res.Metrics[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchPathVis - ResultsFetcher for path visualization results
+func FetchPathVis(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetPathVisWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Paths), func(i int) (*int64, *int64, interface{}) {
+		r := res.Paths[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchHTTPServerResults - ResultsFetcher for HTTP server results
+func FetchHTTPServerResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetHTTPServerResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchPageLoadResults - ResultsFetcher for page load results
+func FetchPageLoadResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetPageLoadResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchWebTransactionResults - ResultsFetcher for web transaction results
+func FetchWebTransactionResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetWebTransactionResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchFTPServerResults - ResultsFetcher for FTP server results
+func FetchFTPServerResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetFTPServerResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchDNSServerResults - ResultsFetcher for DNS server results
+func FetchDNSServerResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetDNSServerResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchDNSTraceResults - ResultsFetcher for DNS trace results
+func FetchDNSTraceResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetDNSTraceResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchDNSSecResults - ResultsFetcher for DNSSEC results
+func FetchDNSSecResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetDNSSecResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchBGPMetrics - ResultsFetcher for BGP metrics, with the monitor ID as AgentID
+func FetchBGPMetrics(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetBGPMetricsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Metrics), func(i int) (*int64, *int64, interface{}) {
+		r := res.Metrics[i]
+		return r.MonitorID, r.RoundID, r
+	}), nil
+}
+
+// FetchRTPStreamResults - ResultsFetcher for voice (RTP stream) results
+func FetchRTPStreamResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetRTPStreamResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchSIPServerResults - ResultsFetcher for SIP server results
+func FetchSIPServerResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetSIPServerResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
+
+// FetchAgentAgentResults - ResultsFetcher for agent to agent results
+func FetchAgentAgentResults(ctx context.Context, c *Client, testID int64, opts *ResultsOptions) ([]PollResult, error) {
+	res, err := c.GetAgentAgentResultsWithContext(ctx, testID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pollResults(testID, len(res.Results), func(i int) (*int64, *int64, interface{}) {
+		r := res.Results[i]
+		return r.AgentID, r.RoundID, r
+	}), nil
+}
@@ -0,0 +1,198 @@
+package thousandeyes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundsServer serves net metrics of agents 10 and 20 for the given rounds,
+// recording the from parameters of the requests
+type roundsServer struct {
+	mu     sync.Mutex
+	rounds []int64
+	froms  []string
+}
+
+func (s *roundsServer) setRounds(rounds ...int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rounds = rounds
+}
+
+func (s *roundsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.froms = append(s.froms, r.URL.Query().Get("from"))
+	metrics := []string{}
+	for _, round := range s.rounds {
+		for _, agent := range []string{"10", "20"} {
+			metrics = append(metrics, `{"agentId": `+agent+`, "roundId": `+strconv.FormatInt(round, 10)+`}`)
+		}
+	}
+	_, _ = w.Write([]byte(`{"net": {"metrics": [` + strings.Join(metrics, ",") + `]}}`))
+}
+
+func collect(polled *[]PollResult) func(PollResult) error {
+	return func(r PollResult) error {
+		*polled = append(*polled, r)
+		return nil
+	}
+}
+
+func fromParam(round int64) string {
+	return time.Unix(round, 0).UTC().Format(resultsTimeFormat)
+}
+
+func TestPoller_Poll(t *testing.T) {
+	setup()
+	defer teardown()
+	rounds := &roundsServer{}
+	mux.Handle("/net/metrics/1.json", rounds)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	poller := &Poller{Client: client, TestIDs: []int64{1}}
+
+	var polled []PollResult
+	rounds.setRounds(100)
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 2)
+	assert.Equal(t, int64(1), polled[0].TestID)
+	assert.Equal(t, int64(10), polled[0].AgentID)
+	assert.Equal(t, int64(100), polled[0].RoundID)
+	assert.IsType(t, NetMetric{}, polled[0].Result)
+
+	polled = nil
+	rounds.setRounds(100, 200)
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 2)
+	assert.Equal(t, int64(200), polled[0].RoundID)
+	assert.Equal(t, int64(200), polled[1].RoundID)
+
+	polled = nil
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 0)
+	assert.Equal(t, []string{"", fromParam(101), fromParam(201)}, rounds.froms)
+}
+
+func TestPoller_Checkpoint(t *testing.T) {
+	setup()
+	defer teardown()
+	rounds := &roundsServer{}
+	mux.Handle("/net/metrics/1.json", rounds)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+
+	checkpoint, err := store.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, Checkpoint{}, checkpoint)
+
+	var polled []PollResult
+	rounds.setRounds(100, 200)
+	poller := &Poller{Client: client, TestIDs: []int64{1}, Window: "1h", Checkpoints: store}
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 4)
+	checkpoint, err = store.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, Checkpoint{1: {10: 200, 20: 200}}, checkpoint)
+
+	// A new poller resumes from the checkpoint
+	polled = nil
+	rounds.setRounds(200, 300)
+	poller = &Poller{Client: client, TestIDs: []int64{1}, Window: "1h", Checkpoints: store}
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 2)
+	assert.Equal(t, int64(300), polled[0].RoundID)
+	assert.Equal(t, []string{"", fromParam(201)}, rounds.froms)
+}
+
+func TestPoller_AgentTimeout(t *testing.T) {
+	poller := &Poller{TestIDs: []int64{1}}
+	poller.checkpoint = Checkpoint{1: {10: 10000, 20: 9000, 30: 100}}
+
+	// Agent 30 no longer reports and stops holding back From
+	opts := poller.options(1)
+	assert.Equal(t, time.Unix(9001, 0), opts.From)
+	assert.Equal(t, Checkpoint{1: {10: 10000, 20: 9000}}, poller.checkpoint)
+
+	poller.AgentTimeout = 10 * time.Minute
+	opts = poller.options(1)
+	assert.Equal(t, time.Unix(10001, 0), opts.From)
+	assert.Equal(t, Checkpoint{1: {10: 10000}}, poller.checkpoint)
+}
+
+func TestPoller_HandleError(t *testing.T) {
+	setup()
+	defer teardown()
+	rounds := &roundsServer{}
+	mux.Handle("/net/metrics/1.json", rounds)
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	poller := &Poller{Client: client, TestIDs: []int64{1}}
+
+	rounds.setRounds(100, 200)
+	handled := 0
+	failure := errors.New("failure")
+	err := poller.Poll(context.Background(), func(r PollResult) error {
+		handled++
+		if r.RoundID == 200 && r.AgentID == 20 {
+			return failure
+		}
+		return nil
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 4, handled)
+
+	// The results of the failed round are passed again
+	var polled []PollResult
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 2)
+	assert.Equal(t, int64(200), polled[0].RoundID)
+}
+
+func TestPoller_FetchError(t *testing.T) {
+	setup()
+	defer teardown()
+	rounds := &roundsServer{}
+	rounds.setRounds(100)
+	mux.Handle("/net/metrics/2.json", rounds)
+	mux.HandleFunc("/net/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	logger := &recordingLogger{}
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo", Logger: logger}
+	poller := &Poller{Client: client, TestIDs: []int64{1, 2}}
+
+	var polled []PollResult
+	assert.Nil(t, poller.Poll(context.Background(), collect(&polled)))
+	assert.Len(t, polled, 2)
+	assert.Equal(t, int64(2), polled[0].TestID)
+	assert.Len(t, logger.messages, 1)
+	assert.True(t, strings.HasPrefix(logger.messages[0], "error Could not poll test results"))
+}
+
+func TestPoller_Stream(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/voice/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"voice": {"metrics": [{"agentId": 10, "targetAgentId": 20, "roundId": 100, "mos": 4.1}]}}`))
+	})
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	poller := &Poller{Client: client, TestIDs: []int64{1}, Fetch: FetchRTPStreamResults, Interval: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, errc := poller.Stream(ctx)
+	r := <-results
+	assert.Equal(t, Float64(4.1), r.Result.(RTPStreamResult).MOS)
+	cancel()
+	for range results {
+		t.Error("results of the same round must not be passed again")
+	}
+	assert.Equal(t, context.Canceled, <-errc)
+}
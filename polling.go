@@ -0,0 +1,100 @@
+package thousandeyes
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultPollInterval is the Poller and AlertWatcher interval when none is
+// set
+const defaultPollInterval = time.Minute
+
+// runEvery calls poll, then again after each interval, until ctx is done
+// or poll returns an error
+func runEvery(ctx context.Context, interval time.Duration, poll func() error) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		if err := poll(); err != nil {
+			return err
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// runInBackground calls run in a goroutine, then done, returning a channel
+// receiving the error of run which is closed afterwards
+func runInBackground(run func() error, done func()) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer done()
+		errc <- run()
+	}()
+	return errc
+}
+
+// loadJSONFile decodes the JSON file at path into v, leaving v unchanged
+// when the file does not exist
+func loadJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// saveJSONFile replaces the file at path with the JSON encoding of v.  The
+// file is replaced atomically, so that a crash never leaves it truncated.
+func saveJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces the file at path with data through a temporary
+// file, which is synced before being renamed so that the new content is
+// on disk when it replaces the old
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	// Sync the directory so that the rename itself survives a power loss.
+	// Not every platform supports it, so failures are ignored.
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		_ = dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package thousandeyes
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_runEvery(t *testing.T) {
+	calls := 0
+	failure := errors.New("failure")
+	err := runEvery(context.Background(), time.Millisecond, func() error {
+		calls++
+		if calls == 3 {
+			return failure
+		}
+		return nil
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 3, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, runEvery(ctx, time.Minute, func() error { return nil }))
+}
+
+func Test_runInBackground(t *testing.T) {
+	done := make(chan struct{})
+	failure := errors.New("failure")
+	errc := runInBackground(func() error { return failure }, func() { close(done) })
+	assert.Equal(t, failure, <-errc)
+	<-done
+	_, ok := <-errc
+	assert.False(t, ok)
+}
+
+func Test_jsonFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	v := map[string]int{"a": 1}
+	// A missing file leaves v unchanged
+	assert.Nil(t, loadJSONFile(path, &v))
+	assert.Equal(t, map[string]int{"a": 1}, v)
+
+	assert.Nil(t, saveJSONFile(path, map[string]int{"b": 2}))
+	loaded := map[string]int{}
+	assert.Nil(t, loadJSONFile(path, &loaded))
+	assert.Equal(t, map[string]int{"b": 2}, loaded)
+
+	// No temporary file is left behind
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("not json"), 0600))
+	assert.NotNil(t, loadJSONFile(path, &loaded))
+	assert.True(t, os.IsNotExist(saveJSONFile(filepath.Join(path+".d", "x.json"), v)))
+}
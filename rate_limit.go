@@ -0,0 +1,191 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit contains data representing rate limit headers returned in
+// ThousandEyes API responses.  int64 everywhere for ease of interacting
+// with time values.
+type RateLimit struct {
+	Limit              int64
+	Remaining          int64
+	Reset              int64
+	LastRemaining      int64
+	ConcurrentMessages []time.Time
+}
+
+// RateLimitState tracks the organization and instant test rate limits
+// reported by the API.  It is safe for concurrent use, and may be shared
+// between Clients of the same organization so that they pace against a
+// single budget.
+type RateLimitState struct {
+	mu          sync.Mutex
+	org         RateLimit
+	instantTest RateLimit
+}
+
+// NewRateLimitState creates an empty rate limit state
+func NewRateLimitState() *RateLimitState {
+	return &RateLimitState{}
+}
+
+// defaultRateLimits paces clients without RateLimits, such as those not
+// created by NewClient
+var defaultRateLimits = NewRateLimitState()
+
+// rateLimits returns the client's RateLimits, defaulting to the package
+// level state
+func (c *Client) rateLimits() *RateLimitState {
+	if c.RateLimits == nil {
+		return defaultRateLimits
+	}
+	return c.RateLimits
+}
+
+// Org returns a copy of the current organization rate limit
+func (s *RateLimitState) Org() RateLimit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyRateLimit(s.org)
+}
+
+// InstantTest returns a copy of the current instant test rate limit
+func (s *RateLimitState) InstantTest() RateLimit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyRateLimit(s.instantTest)
+}
+
+// forRequest returns a copy of the rate limit which applies to req
+func (s *RateLimitState) forRequest(req *http.Request) RateLimit {
+	if isInstantTest(req) {
+		return s.InstantTest()
+	}
+	return s.Org()
+}
+
+func copyRateLimit(r RateLimit) RateLimit {
+	if r.ConcurrentMessages != nil {
+		r.ConcurrentMessages = append([]time.Time{}, r.ConcurrentMessages...)
+	}
+	return r
+}
+
+// setDelay determines the pause time needed to prevent invoking rate limiting
+func (s *RateLimitState) setDelay(req *http.Request, resp *http.Response, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Choose which rate limit applies
+	var delay time.Duration
+	var rate *RateLimit
+	if resp == nil {
+		resp = &http.Response{}
+	}
+	if isInstantTest(req) {
+		rate = &s.instantTest
+	} else {
+		rate = &s.org
+	}
+
+	// If the limit is 0, this is either our first request or we are not receiving
+	// rate limit data in the headers
+	if rate.Limit == 0 {
+		return 0
+	}
+
+	// If this is the first time we've sent this particular request and we
+	// aren't at the end of our remaining requests for the period...
+	if rate.Remaining > 1 && resp.StatusCode != 429 {
+		baseDelay := 1.0 / float64(rate.Limit) * float64(time.Minute.Nanoseconds())
+		// The rate limit is per minute, so if there was a zero response time
+		// then the ideal delay would be the one minute divided by the rate.
+		// To account for potential other users, we will multiply by the
+		// difference between the remaining count and our last seen remaining
+		// count.
+		delta := rate.LastRemaining - rate.Remaining
+		if delta < 1 {
+			delta = 1
+		}
+
+		// It's possible that these calls could be made concurrently, in which
+		// case the pacing delay would effectively be divided by the batch size.
+		// To account for this, we track messages sent for this session and
+		// account for any that have delays which have not expired.
+		for i, t := range rate.ConcurrentMessages {
+			if t.Sub(now) >= time.Duration(0) {
+				rate.ConcurrentMessages = rate.ConcurrentMessages[i:]
+				break
+			}
+		}
+
+		delta += int64(len(rate.ConcurrentMessages))
+		delay = time.Duration(baseDelay * float64(delta))
+		rate.ConcurrentMessages = append(rate.ConcurrentMessages, now.Add(delay))
+	} else {
+		// else calculate delay until resume time.
+		// Assume our clock is roughly in sync with the clock setting the resume time.
+		delay = time.Duration((rate.Reset - now.Unix() + 1) * time.Second.Nanoseconds())
+		// ThousandEyes rates reset within one minute (but not guaranteed).
+		// If we exceed a minute wait time, something may be wrong.
+		if delay > time.Minute {
+			delay = time.Minute
+		}
+	}
+	return delay
+}
+
+// storeLimits records the rate limit headers of resp
+func (s *RateLimitState) storeLimits(req *http.Request, resp *http.Response, now time.Time) {
+	// We discard errors, because an error or blank result also return 0
+	if resp.Header == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v := resp.Header.Get("X-Organization-Rate-Limit-Limit"); v != "" {
+		s.org.Limit, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := resp.Header.Get("X-Organization-Rate-Limit-Remaining"); v != "" {
+		s.org.Remaining, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := resp.Header.Get("X-Organization-Rate-Limit-Reset"); v != "" {
+		s.org.Reset = parseReset(v)
+	}
+	if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Limit"); v != "" {
+		s.instantTest.Limit, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Remaining"); v != "" {
+		s.instantTest.Remaining, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := resp.Header.Get("X-Instant-Test-Rate-Limit-Reset"); v != "" {
+		s.instantTest.Reset = parseReset(v)
+	}
+}
+
+// parseReset reads a rate limit reset time, which v6 reports in epoch
+// seconds and v7 may report as an RFC 3339 timestamp
+func parseReset(v string) int64 {
+	if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return reset
+	}
+	if reset, err := time.Parse(time.RFC3339, v); err == nil {
+		return reset.Unix()
+	}
+	return 0
+}
+
+// instantTestPath matches v6 instant test paths, such as
+// /v6/instant/agent-to-server, and their v7 counterparts, such as
+// /v7/tests/agent-to-server/instant
+var instantTestPath = regexp.MustCompile(`^/v[0-9]+/((endpoint-)?instant/|.*/instant$)`)
+
+func isInstantTest(req *http.Request) bool {
+	return instantTestPath.MatchString(strings.TrimSuffix(req.URL.Path, ".json"))
+}
@@ -0,0 +1,233 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setDelay(t *testing.T) {
+	now := time.Now()
+
+	var delay time.Duration
+	var req *http.Request
+	var resp *http.Response
+	rates := NewRateLimitState()
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
+	resp = &http.Response{}
+	resp.Header = make(map[string][]string)
+
+	// Test initial requests, for which rate limit data is not available
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, time.Duration(0), delay)
+
+	// Test subsequent requests with rate limit data
+	// Old concurrent messages should be purged.
+	rates.org = RateLimit{
+		Limit:         240,
+		Remaining:     100,
+		Reset:         now.Add(30 * time.Second).Unix(),
+		LastRemaining: 101,
+		ConcurrentMessages: []time.Time{
+			now.Add(-1000 * time.Millisecond),
+			now.Add(-750 * time.Millisecond),
+			now,
+			now.Add(250 * time.Millisecond),
+		},
+	}
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 750*time.Millisecond, delay)
+
+	// All complications from valid state:
+	rates.org = RateLimit{
+		Limit:         240,
+		Remaining:     100,
+		Reset:         now.Add(30 * time.Second).Unix(),
+		LastRemaining: 104,
+		ConcurrentMessages: []time.Time{
+			now.Add(1000 * time.Millisecond),
+			now.Add(750 * time.Millisecond),
+			now.Add(500 * time.Millisecond),
+			now.Add(250 * time.Millisecond),
+		},
+	}
+	rates.instantTest = rates.org // Use state to test instant test below
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 2*time.Second, delay)
+
+	// Same result should be obtained for an instant test
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 2*time.Second, delay)
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
+
+	// LastTime over the minimum delay time should result in the minimum delay time if there
+	// are no concurrent messages and last remaining has not decreased by more than 1.
+	rates.org.LastRemaining = 101
+	rates.org.ConcurrentMessages = []time.Time{}
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, time.Duration(250*time.Millisecond), delay)
+
+	// A passed response means we should delay, as this is presently only done
+	// in response to a 429
+	resp.StatusCode = 429
+	delay = rates.setDelay(req, resp, now)
+	assert.Equal(t, 31*time.Second, delay)
+
+	// Remaining messages being under the minimum should also result in waiting
+	// until reset
+	rates.org.Remaining = 1
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 31*time.Second, delay)
+
+	// Test conflicting or invalid states
+	// After reset, LastRemaining may be larger than Remaining
+	rates.org = RateLimit{
+		Limit:              240,
+		Remaining:          240,
+		Reset:              now.Add(30 * time.Second).Unix(),
+		LastRemaining:      2,
+		ConcurrentMessages: []time.Time{},
+	}
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 250*time.Millisecond, delay)
+
+	// Delays over one minute should be shortened to one minute
+	rates.org.Remaining = 0
+	rates.org.Reset = now.Add(120 * time.Second).Unix()
+	delay = rates.setDelay(req, nil, now)
+	assert.Equal(t, 1*time.Minute, delay)
+
+}
+
+func Test_storeLimits(t *testing.T) {
+	now := time.Now()
+	destRate := RateLimit{
+		Limit:     240,
+		Remaining: 2,
+		Reset:     120,
+	}
+
+	var req *http.Request
+	var resp *http.Response
+	rates := NewRateLimitState()
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
+	resp = &http.Response{}
+	resp.Header = make(map[string][]string)
+	resp.Header.Add("X-Organization-Rate-Limit-Limit", "240")
+	resp.Header.Add("X-Organization-Rate-Limit-Remaining", "2")
+	resp.Header.Add("X-Organization-Rate-Limit-Reset", "120")
+	rates.storeLimits(req, resp, now)
+	assert.Equal(t, destRate, rates.org)
+	assert.Equal(t, RateLimit{}, rates.instantTest)
+
+	rates = NewRateLimitState()
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
+	resp = &http.Response{}
+	resp.Header = make(map[string][]string)
+	resp.Header.Add("X-Instant-Test-Rate-Limit-Limit", "240")
+	resp.Header.Add("X-Instant-Test-Rate-Limit-Remaining", "2")
+	resp.Header.Add("X-Instant-Test-Rate-Limit-Reset", "120")
+	rates.storeLimits(req, resp, now)
+	assert.Equal(t, destRate, rates.instantTest)
+	assert.Equal(t, RateLimit{}, rates.org)
+}
+
+func Test_isInstantTest(t *testing.T) {
+	var req *http.Request
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/instant/agent-to-server.json", nil)
+	assert.Equal(t, true, isInstantTest(req))
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/endpoint-instant/agent-to-server.json", nil)
+	assert.Equal(t, true, isInstantTest(req))
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
+	assert.Equal(t, false, isInstantTest(req))
+	req, _ = http.NewRequest("POST", "https://api.thousandeyes.com/v7/tests/agent-to-server/instant", nil)
+	assert.Equal(t, true, isInstantTest(req))
+	req, _ = http.NewRequest("GET", "https://api.thousandeyes.com/v7/tests/agent-to-server/1", nil)
+	assert.Equal(t, false, isInstantTest(req))
+}
+
+func Test_parseReset(t *testing.T) {
+	assert.Equal(t, int64(1600000000), parseReset("1600000000"))
+	assert.Equal(t, int64(1600000000), parseReset("2020-09-13T12:26:40Z"))
+	assert.Equal(t, int64(0), parseReset("soon"))
+}
+
+func Test_RateLimitStatePerClient(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Organization-Rate-Limit-Limit", "6000")
+		w.Header().Set("X-Organization-Rate-Limit-Remaining", r.URL.Query().Get("aid"))
+		w.Header().Set("X-Organization-Rate-Limit-Reset", "120")
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+
+	first := NewClient(&ClientOptions{APIEndpoint: server.URL, AccountID: "10"})
+	second := NewClient(&ClientOptions{APIEndpoint: server.URL, AccountID: "20"})
+	_, _ = first.GetAgents()
+	_, _ = second.GetAgents()
+	assert.Equal(t, int64(10), first.RateLimits.Org().Remaining)
+	assert.Equal(t, int64(20), second.RateLimits.Org().Remaining)
+
+	// Clients created from the same options do not share a state
+	opts := ClientOptions{APIEndpoint: server.URL}
+	assert.NotSame(t, NewClient(&opts).RateLimits, NewClient(&opts).RateLimits)
+	assert.Nil(t, opts.RateLimits)
+
+	// Clients sharing a state pace against the same budget
+	shared := NewRateLimitState()
+	first = NewClient(&ClientOptions{APIEndpoint: server.URL, AccountID: "30", RateLimits: shared})
+	second = NewClient(&ClientOptions{APIEndpoint: server.URL, AccountID: "40", RateLimits: shared})
+	_, _ = first.GetAgents()
+	_, _ = second.GetAgents()
+	assert.Equal(t, int64(40), shared.Org().Remaining)
+	assert.Same(t, first.RateLimits, second.RateLimits)
+}
+
+func Test_RateLimitStateConcurrent(t *testing.T) {
+	rates := NewRateLimitState()
+	req, _ := http.NewRequest("GET", "https://api.thousandeyes.com/v6/agents.json", nil)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Add("X-Organization-Rate-Limit-Limit", "6000")
+	resp.Header.Add("X-Organization-Rate-Limit-Remaining", "5000")
+	resp.Header.Add("X-Organization-Rate-Limit-Reset", "120")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			now := time.Now()
+			rates.storeLimits(req, resp, now)
+			rates.setDelay(req, nil, now)
+			_ = rates.Org()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(5000), rates.Org().Remaining)
+	assert.Len(t, rates.Org().ConcurrentMessages, 10)
+}
+
+func Test_RateLimitStateDefault(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Organization-Rate-Limit-Limit", "6000")
+		w.Header().Set("X-Organization-Rate-Limit-Remaining", "5000")
+		w.Header().Set("X-Organization-Rate-Limit-Reset", "120")
+		_, _ = w.Write([]byte(`{"agents": []}`))
+	})
+	defer func() { defaultRateLimits = NewRateLimitState() }()
+
+	// Clients without RateLimits pace against the package level state
+	client := &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	assert.Same(t, defaultRateLimits, client.rateLimits())
+	_, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5000), defaultRateLimits.Org().Remaining)
+	assert.Same(t, defaultRateLimits, (&Client{}).rateLimits())
+}
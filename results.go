@@ -0,0 +1,122 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// resultsTimeFormat is the format of the from and to parameters
+const resultsTimeFormat = "2006-01-02T15:04:05"
+
+// ResultsOptions - selects the time range, agents and rounds of test
+// results.  With neither Window, From nor a round set, the API returns the
+// most recent round.
+type ResultsOptions struct {
+	// Window is a time window ending now, such as "30m", "12h" or "2d"
+	Window string
+	// From and To select an absolute time range, To defaulting to now
+	From time.Time
+	To   time.Time
+	// AgentIDs, when set, drops results from other agents
+	AgentIDs []int64
+	// RoundID, when set, drops results from other rounds.  Round IDs are
+	// the epoch second the round started, so without Window or From the
+	// round's time is requested.
+	RoundID int64
+	// AfterRoundID, when set, drops results from this round and earlier
+	// ones, so that only new rounds are returned.  Without Window or From,
+	// the rounds after it are requested, allowing round by round paging.
+	AfterRoundID int64
+	// MaxPages limits the number of result pages fetched, all when 0
+	MaxPages int
+}
+
+// ResultsPages - pagination details of a results response
+type ResultsPages struct {
+	Current *int    `json:"current,omitempty"`
+	Next    *string `json:"next,omitempty"`
+}
+
+// query returns the query string parameters for page
+func (o *ResultsOptions) query(page int) url.Values {
+	q := url.Values{}
+	if o != nil {
+		from, to := o.From, o.To
+		if o.Window == "" && from.IsZero() {
+			// Round IDs are the epoch second the round started
+			switch {
+			case o.RoundID != 0:
+				from = time.Unix(o.RoundID, 0)
+				if to.IsZero() {
+					to = from
+				}
+			case o.AfterRoundID != 0:
+				from = time.Unix(o.AfterRoundID+1, 0)
+			}
+		}
+		if o.Window != "" {
+			q.Set("window", o.Window)
+		}
+		if !from.IsZero() {
+			q.Set("from", from.UTC().Format(resultsTimeFormat))
+		}
+		if !to.IsZero() {
+			q.Set("to", to.UTC().Format(resultsTimeFormat))
+		}
+	}
+	if page > 1 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	return q
+}
+
+// includes reports whether the result of an agent and round is selected
+func (o *ResultsOptions) includes(agentID, roundID *int64) bool {
+	if o == nil {
+		return true
+	}
+	if o.RoundID != 0 && roundID != nil && *roundID != o.RoundID {
+		return false
+	}
+	if o.AfterRoundID != 0 && roundID != nil && *roundID <= o.AfterRoundID {
+		return false
+	}
+	if len(o.AgentIDs) == 0 || agentID == nil {
+		return true
+	}
+	for _, id := range o.AgentIDs {
+		if id == *agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// getResults fetches each page of results from path, passing the
+// responses to decode, which returns the pagination details of the page
+func (c *Client) getResults(ctx context.Context, path string, opts *ResultsOptions, decode func(resp *http.Response) (*ResultsPages, error)) error {
+	for page := 1; ; page++ {
+		p := path
+		if q := opts.query(page); len(q) > 0 {
+			p += "?" + q.Encode()
+		}
+		resp, err := c.get(ctx, p)
+		if err != nil {
+			return err
+		}
+		pages, err := decode(resp)
+		if err != nil {
+			return fmt.Errorf("Could not decode JSON response: %v", err)
+		}
+		if pages == nil || pages.Next == nil || *pages.Next == "" {
+			return nil
+		}
+		if opts != nil && opts.MaxPages > 0 && page >= opts.MaxPages {
+			return nil
+		}
+	}
+}
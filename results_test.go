@@ -0,0 +1,88 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsOptions_query(t *testing.T) {
+	var opts *ResultsOptions
+	assert.Equal(t, "", opts.query(1).Encode())
+	assert.Equal(t, "page=2", opts.query(2).Encode())
+
+	opts = &ResultsOptions{Window: "12h"}
+	assert.Equal(t, "window=12h", opts.query(1).Encode())
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	opts = &ResultsOptions{
+		From: time.Date(2022, 3, 1, 12, 0, 0, 0, loc),
+		To:   time.Date(2022, 3, 1, 13, 30, 0, 0, loc),
+	}
+	assert.Equal(t, "from=2022-03-01T10%3A00%3A00&page=3&to=2022-03-01T11%3A30%3A00", opts.query(3).Encode())
+
+	// Rounds select their time unless a range is set
+	opts = &ResultsOptions{RoundID: 1646128800}
+	assert.Equal(t, "from=2022-03-01T10%3A00%3A00&to=2022-03-01T10%3A00%3A00", opts.query(1).Encode())
+	opts = &ResultsOptions{AfterRoundID: 1646128800}
+	assert.Equal(t, "from=2022-03-01T10%3A00%3A01", opts.query(1).Encode())
+	opts = &ResultsOptions{Window: "1d", RoundID: 1646128800, AfterRoundID: 1646128800}
+	assert.Equal(t, "window=1d", opts.query(1).Encode())
+}
+
+func TestResultsOptions_includes(t *testing.T) {
+	var opts *ResultsOptions
+	assert.True(t, opts.includes(Int64(1), Int64(100)))
+
+	opts = &ResultsOptions{AgentIDs: []int64{1, 2}, AfterRoundID: 100}
+	assert.True(t, opts.includes(Int64(2), Int64(101)))
+	assert.False(t, opts.includes(Int64(3), Int64(101)))
+	assert.False(t, opts.includes(Int64(1), Int64(100)))
+	assert.True(t, opts.includes(nil, nil))
+
+	opts = &ResultsOptions{RoundID: 100}
+	assert.True(t, opts.includes(Int64(3), Int64(100)))
+	assert.False(t, opts.includes(Int64(3), Int64(101)))
+}
+
+func TestClient_getResultsPages(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	var pages []string
+	mux.HandleFunc("/net/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2h", r.URL.Query().Get("window"))
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "" {
+			_, _ = w.Write([]byte(`{"net":{"metrics":[{"agentId":1,"roundId":100}]},"pages":{"current":1,"next":"https://api.thousandeyes.com/v6/net/metrics/1.json?window=2h&page=2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"net":{"metrics":[{"agentId":1,"roundId":200}]},"pages":{"current":2}}`))
+	})
+
+	res, err := client.GetNetMetrics(1, &ResultsOptions{Window: "2h"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"", "2"}, pages)
+	assert.Len(t, res.Metrics, 2)
+
+	pages = nil
+	res, err = client.GetNetMetrics(1, &ResultsOptions{Window: "2h", MaxPages: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{""}, pages)
+	assert.Len(t, res.Metrics, 1)
+}
+
+func TestClient_getResultsError(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/net/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetNetMetrics(1, nil)
+	assert.True(t, IsNotFound(err))
+}
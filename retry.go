@@ -0,0 +1,143 @@
+package thousandeyes
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxServerDelay caps delays requested by the API through Retry-After or
+// rate limit reset headers.  ThousandEyes rates reset within one minute.
+const maxServerDelay = time.Minute
+
+// RetryPolicy - controls how API calls which fail with a retryable status
+// code or network error are reattempted.  Request bodies are replayed on
+// every attempt.  Note that retrying POST requests on 5xx responses may
+// repeat a change which the API applied before failing.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.  Each subsequent
+	// retry multiplies the delay by Multiplier, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Multiplier defaults to 2 when unset.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, of each backoff which is
+	// randomly removed to spread out retries from concurrent callers.
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP response codes that are retried.
+	RetryableStatusCodes []int
+	// RetryNetworkErrors enables retries of connection resets and timeouts.
+	RetryNetworkErrors bool
+	// IgnoreRetryAfter disables waiting for the delay requested by the
+	// Retry-After and X-Organization-Rate-Limit-Reset headers when it is
+	// longer than the computed backoff.
+	IgnoreRetryAfter bool
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured:
+// requests which are rate limited are retried once, after the rate limit
+// resets.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          2,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt may be retried
+func (p *RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return p.RetryNetworkErrors && isNetworkError(err)
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, counting from 1
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// retryDelay determines the pause before the given retry, taking into
+// account any delay requested by the API in resp
+func (c *Client) retryDelay(p *RetryPolicy, retry int, req *http.Request, resp *http.Response) time.Duration {
+	delay := p.backoff(retry)
+	if p.IgnoreRetryAfter || resp == nil {
+		return delay
+	}
+	if d := retryAfter(resp, time.Now()); d > delay {
+		delay = d
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if d := c.rateLimits().setDelay(req, resp, time.Now()); d > delay {
+			delay = d
+		}
+	}
+	return delay
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or
+// as an HTTP date
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	var delay time.Duration
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(v); err == nil {
+		delay = date.Sub(now)
+	}
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxServerDelay {
+		return maxServerDelay
+	}
+	return delay
+}
+
+// isNetworkError reports whether err is a transient transport failure
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
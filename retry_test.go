@@ -0,0 +1,160 @@
+package thousandeyes
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RetryStatusCodes(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/tests/http-server/new.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"testName":"test"}`, string(body))
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"test":[{"testId":1,"testName":"test"}]}`))
+	})
+
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:          3,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{502, 503, 504},
+		},
+	})
+	res, err := client.CreateHTTPServer(HTTPServer{TestName: String("test")})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, int64(1), *res.TestID)
+}
+
+func TestClient_RetryMaxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:          2,
+			RetryableStatusCodes: []int{502},
+		},
+	})
+	_, err := client.GetAgents()
+	assert.Error(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestClient_RetryDefaultPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/alert-rules/new.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// The body must be replayed on the retry
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"ruleName":"test"}`, string(body))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"alertRuleId":1,"ruleName":"test"}`))
+	})
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := NewClient(&ClientOptions{APIEndpoint: server.URL})
+	res, err := client.CreateAlertRule(AlertRule{RuleName: String("test")})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, int64(1), *res.RuleID)
+
+	// Server errors are not retried by default
+	attempts = 0
+	_, err = client.GetAgents()
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_RetryNetworkErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	attempts := 0
+	mux.HandleFunc("/agents.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Drop the connection without responding
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{"agents":[{"agentId":1}]}`))
+	})
+
+	client := NewClient(&ClientOptions{
+		APIEndpoint: server.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 2, RetryNetworkErrors: true},
+	})
+	res, err := client.GetAgents()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Len(t, *res, 1)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3))
+	assert.Equal(t, time.Second, p.backoff(5))
+
+	p.Multiplier = 3
+	assert.Equal(t, 900*time.Millisecond, p.backoff(3))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.backoff(1)
+		assert.True(t, d > 50*time.Millisecond && d <= 100*time.Millisecond)
+	}
+
+	assert.Equal(t, time.Duration(0), (&RetryPolicy{}).backoff(3))
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Now()
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, time.Duration(0), retryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", "5")
+	assert.Equal(t, 5*time.Second, retryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", now.Add(10*time.Second).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(10*time.Second), float64(retryAfter(resp, now)), float64(time.Second))
+
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, time.Minute, retryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", "soon")
+	assert.Equal(t, time.Duration(0), retryAfter(resp, now))
+}
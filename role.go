@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -81,7 +82,12 @@ func (t *Permission) UnmarshalJSON(data []byte) error {
 
 // GetRoles - get roles
 func (c *Client) GetRoles() (*[]AccountGroupRole, error) {
-	resp, err := c.get("/roles")
+	return c.GetRolesWithContext(context.Background())
+}
+
+// GetRolesWithContext - same as GetRoles, using ctx for cancellation and deadlines
+func (c *Client) GetRolesWithContext(ctx context.Context) (*[]AccountGroupRole, error) {
+	resp, err := c.get(ctx, "/roles")
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +101,12 @@ func (c *Client) GetRoles() (*[]AccountGroupRole, error) {
 
 // GetRole - get role
 func (c *Client) GetRole(id int64) (*AccountGroupRole, error) {
-	resp, err := c.get(fmt.Sprintf("/roles/%d", id))
+	return c.GetRoleWithContext(context.Background(), id)
+}
+
+// GetRoleWithContext - same as GetRole, using ctx for cancellation and deadlines
+func (c *Client) GetRoleWithContext(ctx context.Context, id int64) (*AccountGroupRole, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/roles/%d", id))
 	if err != nil {
 		return nil, err
 	}
@@ -109,24 +120,34 @@ func (c *Client) GetRole(id int64) (*AccountGroupRole, error) {
 
 // DeleteRole - delete role
 func (c *Client) DeleteRole(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/roles/%d/delete", id), nil, nil)
+	return c.DeleteRoleWithContext(context.Background(), id)
+}
+
+// DeleteRoleWithContext - same as DeleteRole, using ctx for cancellation and deadlines
+func (c *Client) DeleteRoleWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/roles/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete role, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete role")
 	}
 	return nil
 }
 
 // UpdateRole - update role
 func (c *Client) UpdateRole(id int64, role AccountGroupRole) (*AccountGroupRole, error) {
-	resp, err := c.post(fmt.Sprintf("/roles/%d/update", id), role, nil)
+	return c.UpdateRoleWithContext(context.Background(), id, role)
+}
+
+// UpdateRoleWithContext - same as UpdateRole, using ctx for cancellation and deadlines
+func (c *Client) UpdateRoleWithContext(ctx context.Context, id int64, role AccountGroupRole) (*AccountGroupRole, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/roles/%d/update", id), role, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to update role, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update role")
 	}
 	var target AccountGroupRole
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -137,12 +158,17 @@ func (c *Client) UpdateRole(id int64, role AccountGroupRole) (*AccountGroupRole,
 
 // CreateRole - create role
 func (c *Client) CreateRole(user AccountGroupRole) (*AccountGroupRole, error) {
-	resp, err := c.post("/roles/new", user, nil)
+	return c.CreateRoleWithContext(context.Background(), user)
+}
+
+// CreateRoleWithContext - same as CreateRole, using ctx for cancellation and deadlines
+func (c *Client) CreateRoleWithContext(ctx context.Context, user AccountGroupRole) (*AccountGroupRole, error) {
+	resp, err := c.post(ctx, "/roles/new", user, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to update role, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update role")
 	}
 	var target AccountGroupRole
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
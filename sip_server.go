@@ -2,6 +2,7 @@ package thousandeyes
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -99,7 +100,12 @@ func (t *SIPServer) AddAlertRule(id int64) {
 
 // GetSIPServer  - get sip server test
 func (c *Client) GetSIPServer(id int64) (*SIPServer, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetSIPServerWithContext(context.Background(), id)
+}
+
+// GetSIPServerWithContext - same as GetSIPServer, using ctx for cancellation and deadlines
+func (c *Client) GetSIPServerWithContext(ctx context.Context, id int64) (*SIPServer, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &SIPServer{}, err
 	}
@@ -147,12 +153,17 @@ func (c *Client) GetSIPServer(id int64) (*SIPServer, error) {
 
 // CreateSIPServer - Create sip server test
 func (c Client) CreateSIPServer(t SIPServer) (*SIPServer, error) {
-	resp, err := c.post("/tests/sip-server/new", t, nil)
+	return c.CreateSIPServerWithContext(context.Background(), t)
+}
+
+// CreateSIPServerWithContext - same as CreateSIPServer, using ctx for cancellation and deadlines
+func (c Client) CreateSIPServerWithContext(ctx context.Context, t SIPServer) (*SIPServer, error) {
+	resp, err := c.post(ctx, "/tests/sip-server/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create sip-server test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create sip-server test")
 	}
 	var target map[string][]SIPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -163,24 +174,34 @@ func (c Client) CreateSIPServer(t SIPServer) (*SIPServer, error) {
 
 // DeleteSIPServer - delete sip server test
 func (c *Client) DeleteSIPServer(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/sip-server/%d/delete", id), nil, nil)
+	return c.DeleteSIPServerWithContext(context.Background(), id)
+}
+
+// DeleteSIPServerWithContext - same as DeleteSIPServer, using ctx for cancellation and deadlines
+func (c *Client) DeleteSIPServerWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/sip-server/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete sip test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete sip test")
 	}
 	return nil
 }
 
 // UpdateSIPServer - - update sip server test
 func (c *Client) UpdateSIPServer(id int64, t SIPServer) (*SIPServer, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/sip-server/%d/update", id), t, nil)
+	return c.UpdateSIPServerWithContext(context.Background(), id, t)
+}
+
+// UpdateSIPServerWithContext - same as UpdateSIPServer, using ctx for cancellation and deadlines
+func (c *Client) UpdateSIPServerWithContext(ctx context.Context, id int64, t SIPServer) (*SIPServer, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/sip-server/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]SIPServer
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -0,0 +1,407 @@
+package thousandeyes
+
+// The Test interface of each test type.  The accessors are written out for
+// every type, so that the compiler checks each of them has the common
+// fields.
+
+// ID - Satisfying the Test interface
+func (t GenericTest) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t GenericTest) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t GenericTest) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t GenericTest) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t AgentAgent) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t AgentAgent) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t AgentAgent) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t AgentAgent) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t AgentServer) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t AgentServer) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t AgentServer) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t AgentServer) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t BGP) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t BGP) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t BGP) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t BGP) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t DNSSec) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t DNSSec) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t DNSSec) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t DNSSec) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t DNSServer) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t DNSServer) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t DNSServer) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t DNSServer) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t DNSTrace) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t DNSTrace) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t DNSTrace) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t DNSTrace) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t FTPServer) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t FTPServer) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t FTPServer) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t FTPServer) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t HTTPServer) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t HTTPServer) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t HTTPServer) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t HTTPServer) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t PageLoad) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t PageLoad) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t PageLoad) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t PageLoad) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t SIPServer) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t SIPServer) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t SIPServer) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t SIPServer) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t RTPStream) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t RTPStream) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t RTPStream) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t RTPStream) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
+
+// ID - Satisfying the Test interface
+func (t WebTransaction) ID() int64 { return int64Value(t.TestID) }
+
+// Name - Satisfying the Test interface
+func (t WebTransaction) Name() string { return stringValue(t.TestName) }
+
+// TestType - Satisfying the Test interface
+func (t WebTransaction) TestType() string { return stringValue(t.Type) }
+
+// Common - Satisfying the Test interface
+func (t WebTransaction) Common() TestCommon {
+	return TestCommon{
+		AlertsEnabled:      t.AlertsEnabled,
+		AlertRules:         t.AlertRules,
+		APILinks:           t.APILinks,
+		CreatedBy:          t.CreatedBy,
+		CreatedDate:        t.CreatedDate,
+		Description:        t.Description,
+		Enabled:            t.Enabled,
+		Groups:             t.Groups,
+		ModifiedBy:         t.ModifiedBy,
+		ModifiedDate:       t.ModifiedDate,
+		SavedEvent:         t.SavedEvent,
+		SharedWithAccounts: t.SharedWithAccounts,
+		TestID:             t.TestID,
+		TestName:           t.TestName,
+		Type:               t.Type,
+		LiveShare:          t.LiveShare,
+	}
+}
@@ -1,10 +1,62 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
+// Test - behaviour shared by every test type, allowing tests of any type
+// to be handled together and switched on by their concrete type
+type Test interface {
+	// ID returns the test ID, or 0 if unset
+	ID() int64
+	// Name returns the test name
+	Name() string
+	// TestType returns the test type, such as "http-server".  It is not
+	// named Type, as test structs have a Type field.
+	TestType() string
+	// Common returns the fields shared by all test types
+	Common() TestCommon
+}
+
+// TestCommon - fields common to every test type.  LiveShare is nil for
+// GenericTest, which does not have it.
+type TestCommon struct {
+	AlertsEnabled      *bool
+	AlertRules         *[]AlertRule
+	APILinks           *[]APILink
+	CreatedBy          *string
+	CreatedDate        *string
+	Description        *string
+	Enabled            *bool
+	Groups             *[]GroupLabel
+	ModifiedBy         *string
+	ModifiedDate       *string
+	SavedEvent         *bool
+	SharedWithAccounts *[]SharedWithAccount
+	TestID             *int64
+	TestName           *string
+	Type               *string
+	LiveShare          *bool
+}
+
+// testTypes maps the "type" of a test to a constructor for its struct
+var testTypes = map[string]func() Test{
+	"agent-to-agent":   func() Test { return &AgentAgent{} },
+	"agent-to-server":  func() Test { return &AgentServer{} },
+	"bgp":              func() Test { return &BGP{} },
+	"dns-dnssec":       func() Test { return &DNSSec{} },
+	"dns-server":       func() Test { return &DNSServer{} },
+	"dns-trace":        func() Test { return &DNSTrace{} },
+	"ftp-server":       func() Test { return &FTPServer{} },
+	"http-server":      func() Test { return &HTTPServer{} },
+	"page-load":        func() Test { return &PageLoad{} },
+	"sip-server":       func() Test { return &SIPServer{} },
+	"voice":            func() Test { return &RTPStream{} },
+	"web-transactions": func() Test { return &WebTransaction{} },
+}
+
 // GenericTest - GenericTest struct to represent all test types
 type GenericTest struct {
 	// Common test fields
@@ -59,7 +111,12 @@ func (t *GenericTest) UnmarshalJSON(data []byte) error {
 
 // GetTests  - get all tests
 func (c *Client) GetTests() (*[]GenericTest, error) {
-	resp, err := c.get("/tests")
+	return c.GetTestsWithContext(context.Background())
+}
+
+// GetTestsWithContext - same as GetTests, using ctx for cancellation and deadlines
+func (c *Client) GetTestsWithContext(ctx context.Context) (*[]GenericTest, error) {
+	resp, err := c.get(ctx, "/tests")
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +130,12 @@ func (c *Client) GetTests() (*[]GenericTest, error) {
 
 // GetTest - Get test
 func (c *Client) GetTest(id int64) (*GenericTest, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetTestWithContext(context.Background(), id)
+}
+
+// GetTestWithContext - same as GetTest, using ctx for cancellation and deadlines
+func (c *Client) GetTestWithContext(ctx context.Context, id int64) (*GenericTest, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return nil, err
 	}
@@ -84,3 +146,103 @@ func (c *Client) GetTest(id int64) (*GenericTest, error) {
 	test := target["test"][0]
 	return &test, nil
 }
+
+// GetTypedTests - get all tests, each decoded into the struct for its
+// type, e.g. *HTTPServer.  Tests of unknown types are returned as
+// *GenericTest.
+func (c *Client) GetTypedTests() ([]Test, error) {
+	return c.GetTypedTestsWithContext(context.Background())
+}
+
+// GetTypedTestsWithContext - same as GetTypedTests, using ctx for cancellation and deadlines
+func (c *Client) GetTypedTestsWithContext(ctx context.Context) ([]Test, error) {
+	resp, err := c.get(ctx, "/tests")
+	if err != nil {
+		return nil, err
+	}
+	var target map[string][]json.RawMessage
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	tests := make([]Test, 0, len(target["test"]))
+	for _, raw := range target["test"] {
+		test, err := decodeTest(raw)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// GetTypedTest - get a test, decoded into the struct for its type
+func (c *Client) GetTypedTest(id int64) (Test, error) {
+	return c.GetTypedTestWithContext(context.Background(), id)
+}
+
+// GetTypedTestWithContext - same as GetTypedTest, using ctx for cancellation and deadlines
+func (c *Client) GetTypedTestWithContext(ctx context.Context, id int64) (Test, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
+	if err != nil {
+		return nil, err
+	}
+	var target map[string][]json.RawMessage
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	if len(target["test"]) < 1 {
+		return nil, fmt.Errorf("could not get test %v", id)
+	}
+	return decodeTest(target["test"][0])
+}
+
+// decodeTest decodes a test into the struct matching its "type" field
+func decodeTest(data []byte) (Test, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", err)
+	}
+	newTest, ok := testTypes[header.Type]
+	if !ok {
+		newTest = func() Test { return &GenericTest{} }
+	}
+	test := newTest()
+	if err := json.Unmarshal(data, test); err != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", err)
+	}
+
+	// Apply the same corrections as the type specific Get methods
+	switch t := test.(type) {
+	case *AgentServer:
+		fixed, err := extractPort(*t)
+		if err != nil {
+			return nil, err
+		}
+		*t = fixed
+	case *SIPServer:
+		var auth SIPAuthData
+		if err := json.Unmarshal(data, &auth); err != nil {
+			return nil, fmt.Errorf("could not decode JSON response: %v", err)
+		}
+		if auth.AuthUser != nil {
+			t.TargetSIPCredentials = &auth
+		}
+	}
+	return test, nil
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -61,3 +61,80 @@ func TestClient_GetGenericTestJsonError(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "could not decode JSON response: invalid character 'e' in literal true (expecting 'r')")
 }
+
+func TestClient_GetTypedTests(t *testing.T) {
+	setup()
+	out := `{"test":[
+		{"testId":1,"testName":"web","type":"http-server","enabled":1,"url":"https://test.com","followRedirects":1},
+		{"testId":2,"testName":"net","type":"agent-to-server","enabled":0,"server":"test.com:443","protocol":"TCP"},
+		{"testId":3,"testName":"sip","type":"sip-server","authUser":"user","sipRegistrar":"sip.test.com"},
+		{"testId":4,"testName":"new","type":"something-new","savedEvent":1}
+	]}`
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/tests.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	tests, err := client.GetTypedTests()
+	teardown()
+	assert.Nil(t, err)
+	assert.Len(t, tests, 4)
+
+	httpServer, ok := tests[0].(*HTTPServer)
+	assert.True(t, ok)
+	assert.Equal(t, "https://test.com", *httpServer.URL)
+	assert.Equal(t, true, *httpServer.FollowRedirects)
+
+	agentServer, ok := tests[1].(*AgentServer)
+	assert.True(t, ok)
+	assert.Equal(t, "test.com", *agentServer.Server)
+	assert.Equal(t, 443, *agentServer.Port)
+
+	sipServer, ok := tests[2].(*SIPServer)
+	assert.True(t, ok)
+	assert.Equal(t, "sip.test.com", *sipServer.TargetSIPCredentials.SIPRegistrar)
+
+	_, ok = tests[3].(*GenericTest)
+	assert.True(t, ok)
+
+	assert.Equal(t, int64(2), tests[1].ID())
+	assert.Equal(t, "net", tests[1].Name())
+	assert.Equal(t, "agent-to-server", tests[1].TestType())
+	assert.Equal(t, false, *tests[1].Common().Enabled)
+	assert.Equal(t, true, *tests[3].Common().SavedEvent)
+}
+
+func TestClient_GetTypedTest(t *testing.T) {
+	setup()
+	out := `{"test":[{"testId":1,"testName":"dns","type":"dns-server","domain":"test.com"}]}`
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/tests/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	test, err := client.GetTypedTest(1)
+	teardown()
+	assert.Nil(t, err)
+	dnsServer, ok := test.(*DNSServer)
+	assert.True(t, ok)
+	assert.Equal(t, "test.com", *dnsServer.Domain)
+}
+
+func TestTest_Common(t *testing.T) {
+	for testType, newTest := range testTypes {
+		test := newTest()
+		assert.Equal(t, TestCommon{}, test.Common(), testType)
+		assert.Equal(t, int64(0), test.ID(), testType)
+		assert.Equal(t, "", test.Name(), testType)
+	}
+
+	test := HTTPServer{TestID: Int64(1), TestName: String("web"), Type: String("http-server"), LiveShare: Bool(true)}
+	assert.Equal(t, TestCommon{
+		TestID:    Int64(1),
+		TestName:  String("web"),
+		Type:      String("http-server"),
+		LiveShare: Bool(true),
+	}, test.Common())
+}
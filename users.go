@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -19,7 +20,12 @@ type User struct {
 
 // GetUsers - get users
 func (c *Client) GetUsers() (*[]User, error) {
-	resp, err := c.get("/users")
+	return c.GetUsersWithContext(context.Background())
+}
+
+// GetUsersWithContext - same as GetUsers, using ctx for cancellation and deadlines
+func (c *Client) GetUsersWithContext(ctx context.Context) (*[]User, error) {
+	resp, err := c.get(ctx, "/users")
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +39,12 @@ func (c *Client) GetUsers() (*[]User, error) {
 
 // GetUser - get user
 func (c *Client) GetUser(id int64) (*User, error) {
-	resp, err := c.get(fmt.Sprintf("/users/%d", id))
+	return c.GetUserWithContext(context.Background(), id)
+}
+
+// GetUserWithContext - same as GetUser, using ctx for cancellation and deadlines
+func (c *Client) GetUserWithContext(ctx context.Context, id int64) (*User, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/users/%d", id))
 	if err != nil {
 		return nil, err
 	}
@@ -47,24 +58,34 @@ func (c *Client) GetUser(id int64) (*User, error) {
 
 // DeleteUser - delete user
 func (c *Client) DeleteUser(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/users/%d/delete", id), nil, nil)
+	return c.DeleteUserWithContext(context.Background(), id)
+}
+
+// DeleteUserWithContext - same as DeleteUser, using ctx for cancellation and deadlines
+func (c *Client) DeleteUserWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/users/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete alert rule, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete alert rule")
 	}
 	return nil
 }
 
 // UpdateUser - update user
 func (c *Client) UpdateUser(id int64, user User) (*User, error) {
-	resp, err := c.post(fmt.Sprintf("/users/%d/update", id), user, nil)
+	return c.UpdateUserWithContext(context.Background(), id, user)
+}
+
+// UpdateUserWithContext - same as UpdateUser, using ctx for cancellation and deadlines
+func (c *Client) UpdateUserWithContext(ctx context.Context, id int64, user User) (*User, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/users/%d/update", id), user, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to update alert rule, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update alert rule")
 	}
 	var target User
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -75,12 +96,17 @@ func (c *Client) UpdateUser(id int64, user User) (*User, error) {
 
 // CreateUser - create user
 func (c *Client) CreateUser(user User) (*User, error) {
-	resp, err := c.post("/users/new", user, nil)
+	return c.CreateUserWithContext(context.Background(), user)
+}
+
+// CreateUserWithContext - same as CreateUser, using ctx for cancellation and deadlines
+func (c *Client) CreateUserWithContext(ctx context.Context, user User) (*User, error) {
+	resp, err := c.post(ctx, "/users/new", user, nil)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to update alert rule, response code %d", resp.StatusCode)
+		return nil, unexpectedStatus(resp, "failed to update alert rule")
 	}
 	var target User
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
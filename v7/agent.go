@@ -0,0 +1,55 @@
+package v7
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Agent - a v7 agent
+type Agent struct {
+	AgentID           *string   `json:"agentId,omitempty"`
+	AgentName         *string   `json:"agentName,omitempty"`
+	AgentType         *string   `json:"agentType,omitempty"`
+	AgentState        *string   `json:"agentState,omitempty"`
+	CountryID         *string   `json:"countryId,omitempty"`
+	Enabled           *bool     `json:"enabled,omitempty"`
+	Hostname          *string   `json:"hostname,omitempty"`
+	IPAddresses       *[]string `json:"ipAddresses,omitempty"`
+	PublicIPAddresses *[]string `json:"publicIpAddresses,omitempty"`
+	LastSeen          *string   `json:"lastSeen,omitempty"`
+	Location          *string   `json:"location,omitempty"`
+	Network           *string   `json:"network,omitempty"`
+	Prefix            *string   `json:"prefix,omitempty"`
+	Links             *Links    `json:"_links,omitempty"`
+}
+
+// GetAgents - Get all agents
+func (c *Client) GetAgents(ctx context.Context) ([]Agent, error) {
+	var agents []Agent
+	err := c.list(ctx, "/agents", func(resp *http.Response) (*Links, error) {
+		var target struct {
+			Agents []Agent `json:"agents"`
+			Links  *Links  `json:"_links"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&target); err != nil {
+			return nil, err
+		}
+		agents = append(agents, target.Agents...)
+		return target.Links, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
+// GetAgent - Get agent
+func (c *Client) GetAgent(ctx context.Context, id string) (*Agent, error) {
+	var target Agent
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/agents/%s", id), nil, http.StatusOK, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
@@ -0,0 +1,57 @@
+package v7
+
+import (
+	"context"
+	"net/http"
+)
+
+// AgentServerTest - an agent to server test
+type AgentServerTest struct {
+	Test
+
+	Agents                *[]Agent `json:"agents,omitempty"`
+	BandwidthMeasurements *bool    `json:"bandwidthMeasurements,omitempty"`
+	BGPMeasurements       *bool    `json:"bgpMeasurements,omitempty"`
+	IPv6Policy            *string  `json:"ipv6Policy,omitempty"`
+	MTUMeasurements       *bool    `json:"mtuMeasurements,omitempty"`
+	NetworkMeasurements   *bool    `json:"networkMeasurements,omitempty"`
+	NumPathTraces         *int     `json:"numPathTraces,omitempty"`
+	PathTraceMode         *string  `json:"pathTraceMode,omitempty"`
+	Port                  *int     `json:"port,omitempty"`
+	ProbeMode             *string  `json:"probeMode,omitempty"`
+	Protocol              *string  `json:"protocol,omitempty"`
+	Server                *string  `json:"server,omitempty"`
+	UsePublicBGP          *bool    `json:"usePublicBgp,omitempty"`
+}
+
+// GetAgentServer - Get an agent to server test
+func (c *Client) GetAgentServer(ctx context.Context, id string) (*AgentServerTest, error) {
+	var target AgentServerTest
+	if err := c.do(ctx, http.MethodGet, testPath("agent-to-server", id), nil, http.StatusOK, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// CreateAgentServer - Create an agent to server test
+func (c *Client) CreateAgentServer(ctx context.Context, t AgentServerTest) (*AgentServerTest, error) {
+	var target AgentServerTest
+	if err := c.do(ctx, http.MethodPost, "/tests/agent-to-server", t, http.StatusCreated, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// UpdateAgentServer - Update an agent to server test
+func (c *Client) UpdateAgentServer(ctx context.Context, id string, t AgentServerTest) (*AgentServerTest, error) {
+	var target AgentServerTest
+	if err := c.do(ctx, http.MethodPut, testPath("agent-to-server", id), t, http.StatusOK, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// DeleteAgentServer - Delete an agent to server test
+func (c *Client) DeleteAgentServer(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, testPath("agent-to-server", id), nil, http.StatusNoContent, nil)
+}
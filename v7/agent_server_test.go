@@ -0,0 +1,42 @@
+package v7
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+func TestClient_GetAgentServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/agent-to-server/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"testId":"1","type":"agent-to-server","server":"example.com","port":443,"protocol":"TCP","usePublicBgp":true}`))
+	})
+
+	res, err := client.GetAgentServer(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, &AgentServerTest{
+		Test:         Test{TestID: thousandeyes.String("1"), Type: thousandeyes.String("agent-to-server")},
+		Server:       thousandeyes.String("example.com"),
+		Port:         thousandeyes.Int(443),
+		Protocol:     thousandeyes.String("TCP"),
+		UsePublicBGP: thousandeyes.Bool(true),
+	}, res)
+}
+
+func TestClient_CreateAgentServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/agent-to-server", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"testId":"1","server":"example.com"}`))
+	})
+
+	res, err := client.CreateAgentServer(context.Background(), AgentServerTest{Server: thousandeyes.String("example.com")})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", *res.TestID)
+}
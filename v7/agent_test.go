@@ -0,0 +1,39 @@
+package v7
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetAgents(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/agents", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"agents":[{"agentId":"1","enabled":true}],"_links":{"next":{"href":"` + server.URL + `/v7/agents?cursor=2"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"agents":[{"agentId":"2","enabled":false}]}`))
+	})
+
+	agents, err := client.GetAgents(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, agents, 2)
+	assert.Equal(t, "2", *agents[1].AgentID)
+	assert.False(t, *agents[1].Enabled)
+}
+
+func TestClient_GetAgent(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/agents/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"agentId":"1","agentName":"Seattle","enabled":true}`))
+	})
+
+	agent, err := client.GetAgent(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Seattle", *agent.AgentName)
+}
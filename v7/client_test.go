@@ -0,0 +1,131 @@
+package v7
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+var (
+	// mux is the HTTP request multiplexer used with the test server.
+	mux *http.ServeMux
+
+	// client is the v7 client being tested.
+	client *Client
+
+	// server is a test HTTP server used to provide mock API responses.
+	server *httptest.Server
+)
+
+func setup() {
+	mux = http.NewServeMux()
+	server = httptest.NewServer(mux)
+	client = NewClient(&thousandeyes.ClientOptions{
+		APIEndpoint: server.URL + "/v7",
+		AuthToken:   "foo",
+		Logger:      thousandeyes.NopLogger{},
+	})
+}
+
+func teardown() {
+	server.Close()
+}
+
+func TestNewClient(t *testing.T) {
+	opts := thousandeyes.ClientOptions{AuthToken: "foo"}
+	c := NewClient(&opts)
+	assert.Equal(t, "https://api.thousandeyes.com/v7", c.client.APIEndpoint)
+	assert.Equal(t, thousandeyes.APIVersion7, c.client.APIVersion)
+	// The options passed in are left for use with a v6 client
+	assert.Equal(t, "", opts.APIVersion)
+}
+
+func TestNewClient_SharedOptions(t *testing.T) {
+	// A v6 client built first leaves the options usable for v7
+	opts := thousandeyes.ClientOptions{AuthToken: "foo"}
+	v6 := thousandeyes.NewClient(&opts)
+	c := NewClient(&opts)
+	assert.Equal(t, "https://api.thousandeyes.com/v6", v6.APIEndpoint)
+	assert.Equal(t, "https://api.thousandeyes.com/v7", c.client.APIEndpoint)
+	assert.Equal(t, thousandeyes.ClientOptions{AuthToken: "foo"}, opts)
+}
+
+func TestClient_GetTestsPagination(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests", func(w http.ResponseWriter, r *http.Request) {
+		// No ".json" suffix is added to v7 paths
+		assert.Equal(t, "Bearer foo", r.Header.Get("authorization"))
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"tests":[{"testId":"1","testName":"first","type":"http-server","enabled":true}],
+				"_links":{"self":{"href":"` + server.URL + `/v7/tests"},"next":{"href":"` + server.URL + `/v7/tests?cursor=abc"}}}`))
+		case "abc":
+			_, _ = w.Write([]byte(`{"tests":[{"testId":"2","testName":"second","type":"agent-to-server","enabled":false}],
+				"_links":{"self":{"href":"` + server.URL + `/v7/tests?cursor=abc"}}}`))
+		default:
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	tests, err := client.GetTests(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, tests, 2)
+	assert.Equal(t, "1", *tests[0].TestID)
+	assert.Equal(t, true, *tests[0].Enabled)
+	assert.Equal(t, "second", *tests[1].TestName)
+	assert.Equal(t, false, *tests[1].Enabled)
+}
+
+func TestClient_Errors(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/http-server/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorMessage":"not found"}`))
+	})
+	mux.HandleFunc("/v7/tests/http-server", func(w http.ResponseWriter, r *http.Request) {
+		// A successful status other than the expected one
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, err := client.GetHTTPServer(context.Background(), "1")
+	assert.True(t, thousandeyes.IsNotFound(err))
+
+	_, err = client.CreateHTTPServer(context.Background(), HTTPServerTest{})
+	assert.EqualError(t, err, "POST /v7/tests/http-server: HTTP response code: 200")
+}
+
+// responseClient returns a canned response without a Request
+type responseClient struct{}
+
+func (responseClient) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+	}, nil
+}
+
+func TestClient_ErrorsWithoutRequest(t *testing.T) {
+	c := NewClient(&thousandeyes.ClientOptions{
+		AuthToken:  "foo",
+		HTTPClient: responseClient{},
+		Logger:     thousandeyes.NopLogger{},
+	})
+	_, err := c.CreateHTTPServer(context.Background(), HTTPServerTest{})
+	assert.EqualError(t, err, "POST /tests/http-server: HTTP response code: 200")
+}
+
+func TestClient_relativePath(t *testing.T) {
+	c := NewClient(&thousandeyes.ClientOptions{})
+	assert.Equal(t, "/tests?cursor=abc", c.relativePath("https://api.thousandeyes.com/v7/tests?cursor=abc"))
+	assert.Equal(t, "/agents?cursor=abc", c.relativePath("https://proxy.example.com/v7/agents?cursor=abc"))
+	assert.Equal(t, "/agents", c.relativePath("/agents"))
+}
@@ -0,0 +1,73 @@
+package v7
+
+import (
+	"context"
+	"net/http"
+)
+
+// HTTPServerTest - an HTTP server test
+type HTTPServerTest struct {
+	Test
+
+	Agents                *[]Agent  `json:"agents,omitempty"`
+	AuthType              *string   `json:"authType,omitempty"`
+	BandwidthMeasurements *bool     `json:"bandwidthMeasurements,omitempty"`
+	BGPMeasurements       *bool     `json:"bgpMeasurements,omitempty"`
+	ContentRegex          *string   `json:"contentRegex,omitempty"`
+	DesiredStatusCode     *string   `json:"desiredStatusCode,omitempty"`
+	DNSOverride           *string   `json:"dnsOverride,omitempty"`
+	DownloadLimit         *int64    `json:"downloadLimit,omitempty"`
+	FollowRedirects       *bool     `json:"followRedirects,omitempty"`
+	Headers               *[]string `json:"headers,omitempty"`
+	HTTPTargetTime        *int      `json:"httpTargetTime,omitempty"`
+	HTTPTimeLimit         *int      `json:"httpTimeLimit,omitempty"`
+	HTTPVersion           *int      `json:"httpVersion,omitempty"`
+	IPv6Policy            *string   `json:"ipv6Policy,omitempty"`
+	MTUMeasurements       *bool     `json:"mtuMeasurements,omitempty"`
+	NetworkMeasurements   *bool     `json:"networkMeasurements,omitempty"`
+	NumPathTraces         *int      `json:"numPathTraces,omitempty"`
+	Password              *string   `json:"password,omitempty"`
+	PathTraceMode         *string   `json:"pathTraceMode,omitempty"`
+	PostBody              *string   `json:"postBody,omitempty"`
+	ProbeMode             *string   `json:"probeMode,omitempty"`
+	Protocol              *string   `json:"protocol,omitempty"`
+	SSLVersionID          *string   `json:"sslVersionId,omitempty"`
+	URL                   *string   `json:"url,omitempty"`
+	UseNTLM               *bool     `json:"useNtlm,omitempty"`
+	UsePublicBGP          *bool     `json:"usePublicBgp,omitempty"`
+	UserAgent             *string   `json:"userAgent,omitempty"`
+	Username              *string   `json:"username,omitempty"`
+	VerifyCertificate     *bool     `json:"verifyCertificate,omitempty"`
+}
+
+// GetHTTPServer - Get an HTTP server test
+func (c *Client) GetHTTPServer(ctx context.Context, id string) (*HTTPServerTest, error) {
+	var target HTTPServerTest
+	if err := c.do(ctx, http.MethodGet, testPath("http-server", id), nil, http.StatusOK, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// CreateHTTPServer - Create an HTTP server test
+func (c *Client) CreateHTTPServer(ctx context.Context, t HTTPServerTest) (*HTTPServerTest, error) {
+	var target HTTPServerTest
+	if err := c.do(ctx, http.MethodPost, "/tests/http-server", t, http.StatusCreated, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// UpdateHTTPServer - Update an HTTP server test
+func (c *Client) UpdateHTTPServer(ctx context.Context, id string, t HTTPServerTest) (*HTTPServerTest, error) {
+	var target HTTPServerTest
+	if err := c.do(ctx, http.MethodPut, testPath("http-server", id), t, http.StatusOK, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// DeleteHTTPServer - Delete an HTTP server test
+func (c *Client) DeleteHTTPServer(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, testPath("http-server", id), nil, http.StatusNoContent, nil)
+}
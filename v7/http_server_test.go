@@ -0,0 +1,94 @@
+package v7
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+func TestClient_GetHTTPServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/http-server/281474976710706", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(`{"testId":"281474976710706","testName":"test123","type":"http-server","interval":300,
+			"alertsEnabled":true,"enabled":true,"url":"https://test.com","protocol":"TCP","networkMeasurements":true,
+			"followRedirects":false,"agents":[{"agentId":"48620","agentName":"Seattle, WA"}],
+			"_links":{"self":{"href":"https://api.thousandeyes.com/v7/tests/http-server/281474976710706"}}}`))
+	})
+
+	expected := HTTPServerTest{
+		Test: Test{
+			TestID:        thousandeyes.String("281474976710706"),
+			TestName:      thousandeyes.String("test123"),
+			Type:          thousandeyes.String("http-server"),
+			Interval:      thousandeyes.Int(300),
+			AlertsEnabled: thousandeyes.Bool(true),
+			Enabled:       thousandeyes.Bool(true),
+			Links: &Links{
+				Self: &Link{Href: thousandeyes.String("https://api.thousandeyes.com/v7/tests/http-server/281474976710706")},
+			},
+		},
+		URL:                 thousandeyes.String("https://test.com"),
+		Protocol:            thousandeyes.String("TCP"),
+		NetworkMeasurements: thousandeyes.Bool(true),
+		FollowRedirects:     thousandeyes.Bool(false),
+		Agents: &[]Agent{
+			{AgentID: thousandeyes.String("48620"), AgentName: thousandeyes.String("Seattle, WA")},
+		},
+	}
+
+	res, err := client.GetHTTPServer(context.Background(), "281474976710706")
+	assert.Nil(t, err)
+	assert.Equal(t, &expected, res)
+}
+
+func TestClient_CreateHTTPServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/http-server", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		// Booleans are sent as JSON booleans
+		assert.JSONEq(t, `{"testName":"test","url":"https://test.com","followRedirects":false,"agents":[{"agentId":"1"}]}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"testId":"2","testName":"test"}`))
+	})
+
+	res, err := client.CreateHTTPServer(context.Background(), HTTPServerTest{
+		Test:            Test{TestName: thousandeyes.String("test")},
+		URL:             thousandeyes.String("https://test.com"),
+		FollowRedirects: thousandeyes.Bool(false),
+		Agents:          &[]Agent{{AgentID: thousandeyes.String("1")}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "2", *res.TestID)
+}
+
+func TestClient_UpdateHTTPServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/http-server/2", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		_, _ = w.Write([]byte(`{"testId":"2","testName":"renamed"}`))
+	})
+
+	res, err := client.UpdateHTTPServer(context.Background(), "2", HTTPServerTest{Test: Test{TestName: thousandeyes.String("renamed")}})
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", *res.TestName)
+}
+
+func TestClient_DeleteHTTPServer(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/v7/tests/http-server/2", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.Nil(t, client.DeleteHTTPServer(context.Background(), "2"))
+}
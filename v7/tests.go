@@ -0,0 +1,65 @@
+package v7
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Test - fields common to all v7 test types
+type Test struct {
+	TestID             *string              `json:"testId,omitempty"`
+	TestName           *string              `json:"testName,omitempty"`
+	Type               *string              `json:"type,omitempty"`
+	Interval           *int                 `json:"interval,omitempty"`
+	AlertsEnabled      *bool                `json:"alertsEnabled,omitempty"`
+	Enabled            *bool                `json:"enabled,omitempty"`
+	CreatedBy          *string              `json:"createdBy,omitempty"`
+	CreatedDate        *string              `json:"createdDate,omitempty"`
+	Description        *string              `json:"description,omitempty"`
+	LiveShare          *bool                `json:"liveShare,omitempty"`
+	ModifiedBy         *string              `json:"modifiedBy,omitempty"`
+	ModifiedDate       *string              `json:"modifiedDate,omitempty"`
+	SavedEvent         *bool                `json:"savedEvent,omitempty"`
+	Labels             *[]Label             `json:"labels,omitempty"`
+	SharedWithAccounts *[]SharedWithAccount `json:"sharedWithAccounts,omitempty"`
+	Links              *Links               `json:"_links,omitempty"`
+}
+
+// Label - a label applied to a test
+type Label struct {
+	LabelID *string `json:"labelId,omitempty"`
+	Name    *string `json:"name,omitempty"`
+}
+
+// SharedWithAccount - an account group a test is shared with
+type SharedWithAccount struct {
+	AID  *string `json:"aid,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// GetTests - Get all tests
+func (c *Client) GetTests(ctx context.Context) ([]Test, error) {
+	var tests []Test
+	err := c.list(ctx, "/tests", func(resp *http.Response) (*Links, error) {
+		var target struct {
+			Tests []Test `json:"tests"`
+			Links *Links `json:"_links"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&target); err != nil {
+			return nil, err
+		}
+		tests = append(tests, target.Tests...)
+		return target.Links, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
+
+// testPath returns the path of a test of the given type
+func testPath(testType, id string) string {
+	return fmt.Sprintf("/tests/%s/%s", testType, id)
+}
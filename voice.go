@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -83,7 +84,12 @@ func (t *RTPStream) AddAgent(id int64) {
 
 // GetRTPStream - get voice call test
 func (c *Client) GetRTPStream(id int64) (*RTPStream, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetRTPStreamWithContext(context.Background(), id)
+}
+
+// GetRTPStreamWithContext - same as GetRTPStream, using ctx for cancellation and deadlines
+func (c *Client) GetRTPStreamWithContext(ctx context.Context, id int64) (*RTPStream, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &RTPStream{}, err
 	}
@@ -96,12 +102,17 @@ func (c *Client) GetRTPStream(id int64) (*RTPStream, error) {
 
 // CreateRTPStream - Create voice call test
 func (c Client) CreateRTPStream(t RTPStream) (*RTPStream, error) {
-	resp, err := c.post("/tests/voice/new", t, nil)
+	return c.CreateRTPStreamWithContext(context.Background(), t)
+}
+
+// CreateRTPStreamWithContext - same as CreateRTPStream, using ctx for cancellation and deadlines
+func (c Client) CreateRTPStreamWithContext(ctx context.Context, t RTPStream) (*RTPStream, error) {
+	resp, err := c.post(ctx, "/tests/voice/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create voice test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create voice test")
 	}
 	var target map[string][]RTPStream
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -112,24 +123,34 @@ func (c Client) CreateRTPStream(t RTPStream) (*RTPStream, error) {
 
 // DeleteRTPStream - delete voice call test
 func (c *Client) DeleteRTPStream(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/voice/%d/delete", id), nil, nil)
+	return c.DeleteRTPStreamWithContext(context.Background(), id)
+}
+
+// DeleteRTPStreamWithContext - same as DeleteRTPStream, using ctx for cancellation and deadlines
+func (c *Client) DeleteRTPStreamWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/voice/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete voice test, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete voice test")
 	}
 	return nil
 }
 
 // UpdateRTPStream - update voice call test
 func (c *Client) UpdateRTPStream(id int64, t RTPStream) (*RTPStream, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/voice/%d/update", id), t, nil)
+	return c.UpdateRTPStreamWithContext(context.Background(), id, t)
+}
+
+// UpdateRTPStreamWithContext - same as UpdateRTPStream, using ctx for cancellation and deadlines
+func (c *Client) UpdateRTPStreamWithContext(ctx context.Context, id int64, t RTPStream) (*RTPStream, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/voice/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to update test, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to update test")
 	}
 	var target map[string][]RTPStream
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
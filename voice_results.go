@@ -0,0 +1,251 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// RTPStreamResults - results of a voice (RTP stream) test
+type RTPStreamResults struct {
+	Test    GenericTest
+	Results []RTPStreamResult
+}
+
+// RTPStreamResult - voice quality of the stream from an agent to the
+// target agent in a round.  Loss and Discards are percentages, Jitter
+// (packet delay variation) and Latency are in milliseconds.
+type RTPStreamResult struct {
+	AgentID         *int64   `json:"agentId,omitempty"`
+	AgentName       *string  `json:"agentName,omitempty"`
+	CountryID       *string  `json:"countryId,omitempty"`
+	TargetAgentID   *int64   `json:"targetAgentId,omitempty"`
+	TargetAgentName *string  `json:"targetAgentName,omitempty"`
+	Date            *string  `json:"date,omitempty"`
+	RoundID         *int64   `json:"roundId,omitempty"`
+	ServerIP        *string  `json:"serverIp,omitempty"`
+	MOS             *float64 `json:"mos,omitempty"`
+	MaxMOS          *float64 `json:"maxMos,omitempty"`
+	Jitter          *float64 `json:"jitter,omitempty"`
+	Loss            *float64 `json:"loss,omitempty"`
+	Discards        *float64 `json:"discards,omitempty"`
+	Latency         *float64 `json:"latency,omitempty"`
+	Codec           *string  `json:"codec,omitempty"`
+	DSCP            *string  `json:"dscp,omitempty"`
+	ErrorDetails    *string  `json:"errorDetails,omitempty"`
+	Permalink       *string  `json:"permalink,omitempty"`
+}
+
+// SIPServerResults - results of a SIP server test
+type SIPServerResults struct {
+	Test    GenericTest
+	Results []SIPServerResult
+}
+
+// SIPServerResult - SIP metrics measured by an agent against the server
+// in a round.  Times are in milliseconds.
+type SIPServerResult struct {
+	AgentID      *int64   `json:"agentId,omitempty"`
+	AgentName    *string  `json:"agentName,omitempty"`
+	CountryID    *string  `json:"countryId,omitempty"`
+	Date         *string  `json:"date,omitempty"`
+	RoundID      *int64   `json:"roundId,omitempty"`
+	Server       *string  `json:"server,omitempty"`
+	ServerIP     *string  `json:"serverIp,omitempty"`
+	ResponseCode *int     `json:"responseCode,omitempty"`
+	DNSTime      *float64 `json:"dnsTime,omitempty"`
+	ConnectTime  *float64 `json:"connectTime,omitempty"`
+	RegisterTime *float64 `json:"registerTime,omitempty"`
+	ResponseTime *float64 `json:"responseTime,omitempty"`
+	TotalTime    *float64 `json:"totalTime,omitempty"`
+	ErrorType    *string  `json:"errorType,omitempty"`
+	ErrorDetails *string  `json:"errorDetails,omitempty"`
+	Permalink    *string  `json:"permalink,omitempty"`
+}
+
+// MetricSummary - aggregate of a metric over rounds
+type MetricSummary struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+}
+
+// add includes v in the summary
+func (s *MetricSummary) add(v *float64) {
+	if v == nil {
+		return
+	}
+	if s.Count == 0 || *v < s.Min {
+		s.Min = *v
+	}
+	if s.Count == 0 || *v > s.Max {
+		s.Max = *v
+	}
+	s.Mean += (*v - s.Mean) / float64(s.Count+1)
+	s.Count++
+}
+
+// RTPStreamSummary - voice quality between two agents over rounds
+type RTPStreamSummary struct {
+	AgentID       int64
+	TargetAgentID int64
+	Rounds        int
+	MOS           MetricSummary
+	Jitter        MetricSummary
+	Loss          MetricSummary
+	Discards      MetricSummary
+	Latency       MetricSummary
+}
+
+// SIPServerSummary - SIP metrics of an agent against a server over rounds
+type SIPServerSummary struct {
+	AgentID      int64
+	Server       string
+	Rounds       int
+	Errors       int
+	RegisterTime MetricSummary
+	ResponseTime MetricSummary
+	TotalTime    MetricSummary
+}
+
+// ByAgentPair - aggregates the results per source and target agent,
+// ordered by agent and target agent
+func (r RTPStreamResults) ByAgentPair() []RTPStreamSummary {
+	type key struct{ agent, target int64 }
+	summaries := map[key]*RTPStreamSummary{}
+	keys := []key{}
+	for _, result := range r.Results {
+		k := key{int64Value(result.AgentID), int64Value(result.TargetAgentID)}
+		s, ok := summaries[k]
+		if !ok {
+			s = &RTPStreamSummary{AgentID: k.agent, TargetAgentID: k.target}
+			summaries[k] = s
+			keys = append(keys, k)
+		}
+		s.Rounds++
+		s.MOS.add(result.MOS)
+		s.Jitter.add(result.Jitter)
+		s.Loss.add(result.Loss)
+		s.Discards.add(result.Discards)
+		s.Latency.add(result.Latency)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].agent != keys[j].agent {
+			return keys[i].agent < keys[j].agent
+		}
+		return keys[i].target < keys[j].target
+	})
+	pairs := make([]RTPStreamSummary, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, *summaries[k])
+	}
+	return pairs
+}
+
+// ByAgentServer - aggregates the results per agent and server, ordered by
+// agent and server
+func (r SIPServerResults) ByAgentServer() []SIPServerSummary {
+	type key struct {
+		agent  int64
+		server string
+	}
+	summaries := map[key]*SIPServerSummary{}
+	keys := []key{}
+	for _, result := range r.Results {
+		k := key{int64Value(result.AgentID), stringValue(result.Server)}
+		s, ok := summaries[k]
+		if !ok {
+			s = &SIPServerSummary{AgentID: k.agent, Server: k.server}
+			summaries[k] = s
+			keys = append(keys, k)
+		}
+		s.Rounds++
+		if result.ErrorDetails != nil && *result.ErrorDetails != "" {
+			s.Errors++
+		}
+		s.RegisterTime.add(result.RegisterTime)
+		s.ResponseTime.add(result.ResponseTime)
+		s.TotalTime.add(result.TotalTime)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].agent != keys[j].agent {
+			return keys[i].agent < keys[j].agent
+		}
+		return keys[i].server < keys[j].server
+	})
+	pairs := make([]SIPServerSummary, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, *summaries[k])
+	}
+	return pairs
+}
+
+// GetRTPStreamResults - Get results of a voice (RTP stream) test
+func (c *Client) GetRTPStreamResults(testID int64, opts *ResultsOptions) (*RTPStreamResults, error) {
+	return c.GetRTPStreamResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetRTPStreamResultsWithContext - same as GetRTPStreamResults, using ctx for cancellation and deadlines
+func (c *Client) GetRTPStreamResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*RTPStreamResults, error) {
+	results := RTPStreamResults{Results: []RTPStreamResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/voice/metrics/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Voice struct {
+				Test    GenericTest       `json:"test"`
+				Metrics []RTPStreamResult `json:"metrics"`
+			} `json:"voice"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Voice.Test
+		for _, r := range target.Voice.Metrics {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// GetSIPServerResults - Get results of a SIP server test
+func (c *Client) GetSIPServerResults(testID int64, opts *ResultsOptions) (*SIPServerResults, error) {
+	return c.GetSIPServerResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetSIPServerResultsWithContext - same as GetSIPServerResults, using ctx for cancellation and deadlines
+func (c *Client) GetSIPServerResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*SIPServerResults, error) {
+	results := SIPServerResults{Results: []SIPServerResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/voice/sip-metrics/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Voice struct {
+				Test       GenericTest       `json:"test"`
+				SIPMetrics []SIPServerResult `json:"sipMetrics"`
+			} `json:"voice"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Voice.Test
+		for _, r := range target.Voice.SIPMetrics {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
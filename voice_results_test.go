@@ -0,0 +1,87 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetRTPStreamResults(t *testing.T) {
+	out := `{"voice": {"test": {"testId": 1, "type": "voice"}, "metrics": [
+		{"agentId": 10, "agentName": "Dallas", "targetAgentId": 20, "targetAgentName": "London", "roundId": 100,
+		 "mos": 4.2, "maxMos": 4.4, "jitter": 1.5, "loss": 0, "discards": 0.5, "latency": 60, "codec": "G.711 @ 64 Kbps",
+		 "dscp": "EF (DSCP 46)"},
+		{"agentId": 10, "targetAgentId": 20, "roundId": 200, "mos": 3.6, "jitter": 4.5, "loss": 2, "discards": 1.5, "latency": 80},
+		{"agentId": 30, "targetAgentId": 20, "roundId": 100, "mos": 4.3}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/voice/metrics/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetRTPStreamResults(1, &ResultsOptions{Window: "1h"})
+	assert.Nil(t, err)
+	assert.Equal(t, RTPStreamResult{
+		AgentID:         Int64(10),
+		AgentName:       String("Dallas"),
+		TargetAgentID:   Int64(20),
+		TargetAgentName: String("London"),
+		RoundID:         Int64(100),
+		MOS:             Float64(4.2),
+		MaxMOS:          Float64(4.4),
+		Jitter:          Float64(1.5),
+		Loss:            Float64(0),
+		Discards:        Float64(0.5),
+		Latency:         Float64(60),
+		Codec:           String("G.711 @ 64 Kbps"),
+		DSCP:            String("EF (DSCP 46)"),
+	}, res.Results[0])
+
+	pairs := res.ByAgentPair()
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, int64(10), pairs[0].AgentID)
+	assert.Equal(t, int64(20), pairs[0].TargetAgentID)
+	assert.Equal(t, 2, pairs[0].Rounds)
+	assert.Equal(t, 2, pairs[0].MOS.Count)
+	assert.Equal(t, 3.6, pairs[0].MOS.Min)
+	assert.Equal(t, 4.2, pairs[0].MOS.Max)
+	assert.InDelta(t, 3.9, pairs[0].MOS.Mean, 1e-9)
+	assert.Equal(t, float64(70), pairs[0].Latency.Mean)
+	assert.Equal(t, int64(30), pairs[1].AgentID)
+	assert.Equal(t, 0, pairs[1].Jitter.Count)
+}
+
+func TestClient_GetSIPServerResults(t *testing.T) {
+	out := `{"voice": {"test": {"testId": 2, "type": "sip-server"}, "sipMetrics": [
+		{"agentId": 10, "roundId": 100, "server": "sip.example.com:5060", "serverIp": "192.0.2.5", "responseCode": 200,
+		 "dnsTime": 3, "connectTime": 10, "registerTime": 40, "responseTime": 25, "totalTime": 78},
+		{"agentId": 10, "roundId": 200, "server": "sip.example.com:5060", "responseCode": 408, "errorType": "Timeout",
+		 "errorDetails": "Request timed out"}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/voice/sip-metrics/2.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetSIPServerResults(2, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Float64(40), res.Results[0].RegisterTime)
+	assert.Equal(t, Int(408), res.Results[1].ResponseCode)
+
+	assert.Equal(t, []SIPServerSummary{{
+		AgentID:      10,
+		Server:       "sip.example.com:5060",
+		Rounds:       2,
+		Errors:       1,
+		RegisterTime: MetricSummary{Count: 1, Min: 40, Max: 40, Mean: 40},
+		ResponseTime: MetricSummary{Count: 1, Min: 25, Max: 25, Mean: 25},
+		TotalTime:    MetricSummary{Count: 1, Min: 78, Max: 78, Mean: 78},
+	}}, res.ByAgentServer())
+}
@@ -0,0 +1,144 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// HTTPServerResults - web layer results of an HTTP server test
+type HTTPServerResults struct {
+	Test    GenericTest
+	Results []HTTPServerResult
+}
+
+// HTTPServerResult - HTTP server metrics measured by an agent in a round.
+// Times are in milliseconds and Throughput in bytes per second.
+type HTTPServerResult struct {
+	AgentID      *int64   `json:"agentId,omitempty"`
+	AgentName    *string  `json:"agentName,omitempty"`
+	CountryID    *string  `json:"countryId,omitempty"`
+	Date         *string  `json:"date,omitempty"`
+	RoundID      *int64   `json:"roundId,omitempty"`
+	Server       *string  `json:"server,omitempty"`
+	ServerIP     *string  `json:"serverIp,omitempty"`
+	ResponseCode *int     `json:"responseCode,omitempty"`
+	NumRedirects *int     `json:"numRedirects,omitempty"`
+	DNSTime      *float64 `json:"dnsTime,omitempty"`
+	ConnectTime  *float64 `json:"connectTime,omitempty"`
+	SSLTime      *float64 `json:"sslTime,omitempty"`
+	RedirectTime *float64 `json:"redirectTime,omitempty"`
+	WaitTime     *float64 `json:"waitTime,omitempty"`
+	ReceiveTime  *float64 `json:"receiveTime,omitempty"`
+	ResponseTime *float64 `json:"responseTime,omitempty"`
+	FetchTime    *float64 `json:"fetchTime,omitempty"`
+	TotalTime    *float64 `json:"totalTime,omitempty"`
+	WireSize     *int64   `json:"wireSize,omitempty"`
+	Throughput   *float64 `json:"throughput,omitempty"`
+	SSLVersion   *string  `json:"sslVersion,omitempty"`
+	SSLCipher    *string  `json:"sslCipher,omitempty"`
+	ErrorType    *string  `json:"errorType,omitempty"`
+	ErrorDetails *string  `json:"errorDetails,omitempty"`
+	Permalink    *string  `json:"permalink,omitempty"`
+}
+
+// PageLoadResults - web layer results of a page load test
+type PageLoadResults struct {
+	Test    GenericTest
+	Results []PageLoadResult
+}
+
+// PageLoadResult - page load metrics measured by an agent in a round.
+// Times are in milliseconds and TotalSize in bytes.
+type PageLoadResult struct {
+	AgentID      *int64   `json:"agentId,omitempty"`
+	AgentName    *string  `json:"agentName,omitempty"`
+	CountryID    *string  `json:"countryId,omitempty"`
+	Date         *string  `json:"date,omitempty"`
+	RoundID      *int64   `json:"roundId,omitempty"`
+	ResponseTime *float64 `json:"responseTime,omitempty"`
+	DOMLoadTime  *float64 `json:"domLoadTime,omitempty"`
+	PageLoadTime *float64 `json:"pageLoadTime,omitempty"`
+	NumObjects   *int     `json:"numObjects,omitempty"`
+	NumErrors    *int     `json:"numErrors,omitempty"`
+	TotalSize    *int64   `json:"totalSize,omitempty"`
+	ErrorType    *string  `json:"errorType,omitempty"`
+	ErrorDetails *string  `json:"errorDetails,omitempty"`
+	Permalink    *string  `json:"permalink,omitempty"`
+}
+
+// Agent - returns the agent which measured the result
+func (r HTTPServerResult) Agent() Agent {
+	return Agent{AgentID: r.AgentID, AgentName: r.AgentName, CountryID: r.CountryID}
+}
+
+// Agent - returns the agent which measured the result
+func (r PageLoadResult) Agent() Agent {
+	return Agent{AgentID: r.AgentID, AgentName: r.AgentName, CountryID: r.CountryID}
+}
+
+// GetHTTPServerResults - Get web layer results of an HTTP server test
+func (c *Client) GetHTTPServerResults(testID int64, opts *ResultsOptions) (*HTTPServerResults, error) {
+	return c.GetHTTPServerResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetHTTPServerResultsWithContext - same as GetHTTPServerResults, using ctx for cancellation and deadlines
+func (c *Client) GetHTTPServerResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*HTTPServerResults, error) {
+	results := HTTPServerResults{Results: []HTTPServerResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/web/http-server/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Web struct {
+				Test       GenericTest        `json:"test"`
+				HTTPServer []HTTPServerResult `json:"httpServer"`
+			} `json:"web"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Web.Test
+		for _, r := range target.Web.HTTPServer {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// GetPageLoadResults - Get web layer results of a page load test
+func (c *Client) GetPageLoadResults(testID int64, opts *ResultsOptions) (*PageLoadResults, error) {
+	return c.GetPageLoadResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetPageLoadResultsWithContext - same as GetPageLoadResults, using ctx for cancellation and deadlines
+func (c *Client) GetPageLoadResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*PageLoadResults, error) {
+	results := PageLoadResults{Results: []PageLoadResult{}}
+	err := c.getResults(ctx, fmt.Sprintf("/web/page-load/%d", testID), opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Web struct {
+				Test     GenericTest      `json:"test"`
+				PageLoad []PageLoadResult `json:"pageLoad"`
+			} `json:"web"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Web.Test
+		for _, r := range target.Web.PageLoad {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,90 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetHTTPServerResults(t *testing.T) {
+	out := `{"web": {"test": {"testId": 1, "type": "http-server"}, "httpServer": [
+		{"agentId": 10, "agentName": "Dallas", "roundId": 100, "server": "example.com:443", "serverIp": "192.0.2.1",
+		 "responseCode": 200, "numRedirects": 0, "dnsTime": 5, "connectTime": 10, "sslTime": 20, "redirectTime": 0,
+		 "waitTime": 30, "receiveTime": 2, "responseTime": 67, "fetchTime": 67, "totalTime": 67, "wireSize": 1024,
+		 "throughput": 512000, "sslVersion": "TLSv1.3", "errorType": "None"},
+		{"agentId": 20, "agentName": "London", "roundId": 100, "responseCode": 0, "errorType": "Connect",
+		 "errorDetails": "Connection timed out"}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/http-server/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "6h", r.URL.Query().Get("window"))
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetHTTPServerResults(1, &ResultsOptions{Window: "6h"})
+	assert.Nil(t, err)
+	assert.Equal(t, String("http-server"), res.Test.Type)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, HTTPServerResult{
+		AgentID:      Int64(10),
+		AgentName:    String("Dallas"),
+		RoundID:      Int64(100),
+		Server:       String("example.com:443"),
+		ServerIP:     String("192.0.2.1"),
+		ResponseCode: Int(200),
+		NumRedirects: Int(0),
+		DNSTime:      Float64(5),
+		ConnectTime:  Float64(10),
+		SSLTime:      Float64(20),
+		RedirectTime: Float64(0),
+		WaitTime:     Float64(30),
+		ReceiveTime:  Float64(2),
+		ResponseTime: Float64(67),
+		FetchTime:    Float64(67),
+		TotalTime:    Float64(67),
+		WireSize:     Int64(1024),
+		Throughput:   Float64(512000),
+		SSLVersion:   String("TLSv1.3"),
+		ErrorType:    String("None"),
+	}, res.Results[0])
+
+	res, err = client.GetHTTPServerResults(1, &ResultsOptions{Window: "6h", AgentIDs: []int64{20}})
+	assert.Nil(t, err)
+	assert.Len(t, res.Results, 1)
+	assert.Equal(t, String("Connection timed out"), res.Results[0].ErrorDetails)
+	assert.Equal(t, Int64(20), res.Results[0].Agent().AgentID)
+}
+
+func TestClient_GetPageLoadResults(t *testing.T) {
+	out := `{"web": {"test": {"testId": 2, "type": "page-load"}, "pageLoad": [
+		{"agentId": 10, "agentName": "Dallas", "roundId": 100, "responseTime": 67, "domLoadTime": 800,
+		 "pageLoadTime": 1500, "numObjects": 42, "numErrors": 1, "totalSize": 2048000, "errorType": "None"},
+		{"agentId": 20, "agentName": "London", "roundId": 100, "pageLoadTime": 2500}
+	]}, "pages": {"current": 1}}`
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/page-load/2.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(out))
+	})
+
+	res, err := client.GetPageLoadResults(2, &ResultsOptions{AgentIDs: []int64{10}})
+	assert.Nil(t, err)
+	assert.Equal(t, []PageLoadResult{{
+		AgentID:      Int64(10),
+		AgentName:    String("Dallas"),
+		RoundID:      Int64(100),
+		ResponseTime: Float64(67),
+		DOMLoadTime:  Float64(800),
+		PageLoadTime: Float64(1500),
+		NumObjects:   Int(42),
+		NumErrors:    Int(1),
+		TotalSize:    Int64(2048000),
+		ErrorType:    String("None"),
+	}}, res.Results)
+}
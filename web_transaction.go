@@ -1,6 +1,7 @@
 package thousandeyes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -88,12 +89,17 @@ func (t *WebTransaction) UnmarshalJSON(data []byte) error {
 
 // CreateWebTransaction - Create a web transaction test
 func (c Client) CreateWebTransaction(t WebTransaction) (*WebTransaction, error) {
-	resp, err := c.post("/tests/web-transactions/new", t, nil)
+	return c.CreateWebTransactionWithContext(context.Background(), t)
+}
+
+// CreateWebTransactionWithContext - same as CreateWebTransaction, using ctx for cancellation and deadlines
+func (c Client) CreateWebTransactionWithContext(ctx context.Context, t WebTransaction) (*WebTransaction, error) {
+	resp, err := c.post(ctx, "/tests/web-transactions/new", t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 201 {
-		return &t, fmt.Errorf("failed to create web transaction, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to create web transaction")
 	}
 	var target map[string][]WebTransaction
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -104,7 +110,12 @@ func (c Client) CreateWebTransaction(t WebTransaction) (*WebTransaction, error)
 
 // GetWebTransaction - get a web transactiont test
 func (c *Client) GetWebTransaction(id int64) (*WebTransaction, error) {
-	resp, err := c.get(fmt.Sprintf("/tests/%d", id))
+	return c.GetWebTransactionWithContext(context.Background(), id)
+}
+
+// GetWebTransactionWithContext - same as GetWebTransaction, using ctx for cancellation and deadlines
+func (c *Client) GetWebTransactionWithContext(ctx context.Context, id int64) (*WebTransaction, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/tests/%d", id))
 	if err != nil {
 		return &WebTransaction{}, err
 	}
@@ -117,24 +128,34 @@ func (c *Client) GetWebTransaction(id int64) (*WebTransaction, error) {
 
 // DeleteWebTransaction - delete a web transactiont est
 func (c *Client) DeleteWebTransaction(id int64) error {
-	resp, err := c.post(fmt.Sprintf("/tests/web-transactions/%d/delete", id), nil, nil)
+	return c.DeleteWebTransactionWithContext(context.Background(), id)
+}
+
+// DeleteWebTransactionWithContext - same as DeleteWebTransaction, using ctx for cancellation and deadlines
+func (c *Client) DeleteWebTransactionWithContext(ctx context.Context, id int64) error {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/web-transactions/%d/delete", id), nil, nil)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("failed to delete http server, response code %d", resp.StatusCode)
+		return unexpectedStatus(resp, "failed to delete http server")
 	}
 	return nil
 }
 
 // UpdateWebTransaction - update a web transaction test
 func (c *Client) UpdateWebTransaction(id int64, t WebTransaction) (*WebTransaction, error) {
-	resp, err := c.post(fmt.Sprintf("/tests/web-transactions/%d/update", id), t, nil)
+	return c.UpdateWebTransactionWithContext(context.Background(), id, t)
+}
+
+// UpdateWebTransactionWithContext - same as UpdateWebTransaction, using ctx for cancellation and deadlines
+func (c *Client) UpdateWebTransactionWithContext(ctx context.Context, id int64, t WebTransaction) (*WebTransaction, error) {
+	resp, err := c.post(ctx, fmt.Sprintf("/tests/web-transactions/%d/update", id), t, nil)
 	if err != nil {
 		return &t, err
 	}
 	if resp.StatusCode != 200 {
-		return &t, fmt.Errorf("failed to web transaction, response code %d", resp.StatusCode)
+		return &t, unexpectedStatus(resp, "failed to web transaction")
 	}
 	var target map[string][]WebTransaction
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
@@ -0,0 +1,233 @@
+package thousandeyes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// WebTransactionResults - results of a web transaction test
+type WebTransactionResults struct {
+	Test    GenericTest
+	Results []WebTransactionResult
+}
+
+// WebTransactionResult - a transaction run by an agent in a round.  Times
+// are in milliseconds.
+type WebTransactionResult struct {
+	AgentID         *int64                  `json:"agentId,omitempty"`
+	AgentName       *string                 `json:"agentName,omitempty"`
+	CountryID       *string                 `json:"countryId,omitempty"`
+	Date            *string                 `json:"date,omitempty"`
+	RoundID         *int64                  `json:"roundId,omitempty"`
+	ResponseTime    *float64                `json:"responseTime,omitempty"`
+	TransactionTime *float64                `json:"transactionTime,omitempty"`
+	Completion      *float64                `json:"completion,omitempty"`
+	NumSteps        *int                    `json:"numSteps,omitempty"`
+	NumPages        *int                    `json:"numPages,omitempty"`
+	Steps           *[]WebTransactionStep   `json:"steps,omitempty"`
+	Markers         *[]WebTransactionMarker `json:"markers,omitempty"`
+	ErrorType       *string                 `json:"errorType,omitempty"`
+	ErrorDetails    *string                 `json:"errorDetails,omitempty"`
+	ScreenshotURL   *string                 `json:"screenshotUrl,omitempty"`
+	Permalink       *string                 `json:"permalink,omitempty"`
+}
+
+// WebTransactionStep - timing of a step of the transaction script, named
+// by the API in StepName.  StepNum starts at 1.
+type WebTransactionStep struct {
+	StepNum      *int     `json:"stepNum,omitempty"`
+	StepName     *string  `json:"stepName,omitempty"`
+	StartTime    *float64 `json:"startTime,omitempty"`
+	Duration     *float64 `json:"duration,omitempty"`
+	ErrorDetails *string  `json:"errorDetails,omitempty"`
+}
+
+// WebTransactionMarker - timing of a marker set by the transaction script
+type WebTransactionMarker struct {
+	Name      *string  `json:"name,omitempty"`
+	StartTime *float64 `json:"startTime,omitempty"`
+	Duration  *float64 `json:"duration,omitempty"`
+}
+
+// WebTransactionNamedStep - a step name of the script along with its
+// result, nil when the transaction did not reach the step
+type WebTransactionNamedStep struct {
+	Name string
+	Step *WebTransactionStep
+}
+
+// WebTransactionNamedMarker - a marker name of the script along with its
+// timing, nil when the transaction did not reach the marker
+type WebTransactionNamedMarker struct {
+	Name   string
+	Marker *WebTransactionMarker
+}
+
+// Agent - returns the agent which ran the transaction
+func (r WebTransactionResult) Agent() Agent {
+	return Agent{AgentID: r.AgentID, AgentName: r.AgentName, CountryID: r.CountryID}
+}
+
+// AlignSteps - pairs the step names of the script, in order, with the
+// step results of the transaction.  Steps are matched on StepName, or on
+// StepNum for results without a name, and are nil when not reached.
+func (r WebTransactionResult) AlignSteps(names []string) []WebTransactionNamedStep {
+	aligned := make([]WebTransactionNamedStep, len(names))
+	for i, name := range names {
+		aligned[i].Name = name
+	}
+	if r.Steps == nil {
+		return aligned
+	}
+	for i := range *r.Steps {
+		step := &(*r.Steps)[i]
+		if step.StepName != nil && *step.StepName != "" {
+			for j := range aligned {
+				if aligned[j].Name == *step.StepName && aligned[j].Step == nil {
+					aligned[j].Step = step
+					break
+				}
+			}
+		} else if step.StepNum != nil && *step.StepNum >= 1 && *step.StepNum <= len(aligned) {
+			aligned[*step.StepNum-1].Step = step
+		}
+	}
+	return aligned
+}
+
+// Marker - returns the timing of the named marker, or nil
+func (r WebTransactionResult) Marker(name string) *WebTransactionMarker {
+	if r.Markers == nil {
+		return nil
+	}
+	for i, marker := range *r.Markers {
+		if marker.Name != nil && *marker.Name == name {
+			return &(*r.Markers)[i]
+		}
+	}
+	return nil
+}
+
+// AlignMarkers - pairs the marker names of the script, as returned by
+// TransactionMarkerNames, with the marker timings of the transaction
+func (r WebTransactionResult) AlignMarkers(names []string) []WebTransactionNamedMarker {
+	aligned := make([]WebTransactionNamedMarker, len(names))
+	for i, name := range names {
+		aligned[i] = WebTransactionNamedMarker{Name: name, Marker: r.Marker(name)}
+	}
+	return aligned
+}
+
+// markerStartPattern matches the markers.start calls of a script
+var markerStartPattern = regexp.MustCompile(`markers\.start\(\s*(?:'([^']*)'|"([^"]*)"|` + "`([^`]*)`" + `)\s*\)`)
+
+// TransactionMarkerNames - returns the names of the markers started by a
+// transaction script, in order and without duplicates.  Commented out
+// markers.start calls are ignored.
+func TransactionMarkerNames(script string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, m := range markerStartPattern.FindAllStringSubmatch(stripComments(script), -1) {
+		name := m[1] + m[2] + m[3]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MarkerNames - returns the marker names of the test's TransactionScript
+func (t WebTransaction) MarkerNames() []string {
+	return TransactionMarkerNames(stringValue(t.TransactionScript))
+}
+
+// stripComments removes the // and /* */ comments of a script, leaving
+// string literals untouched
+func stripComments(script string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(ch)
+			if ch == '\\' && i+1 < len(script) {
+				i++
+				b.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			b.WriteByte(ch)
+		case strings.HasPrefix(script[i:], "//"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				return b.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// GetWebTransactionResults - Get results of a web transaction test
+func (c *Client) GetWebTransactionResults(testID int64, opts *ResultsOptions) (*WebTransactionResults, error) {
+	return c.GetWebTransactionResultsWithContext(context.Background(), testID, opts)
+}
+
+// GetWebTransactionResultsWithContext - same as GetWebTransactionResults, using ctx for cancellation and deadlines
+func (c *Client) GetWebTransactionResultsWithContext(ctx context.Context, testID int64, opts *ResultsOptions) (*WebTransactionResults, error) {
+	return c.getWebTransactionResults(ctx, fmt.Sprintf("/web/web-transactions/%d", testID), opts)
+}
+
+// GetWebTransactionDetail - Get the step and marker timings of the
+// transaction run by an agent in a round
+func (c *Client) GetWebTransactionDetail(testID, agentID, roundID int64) (*WebTransactionResults, error) {
+	return c.GetWebTransactionDetailWithContext(context.Background(), testID, agentID, roundID)
+}
+
+// GetWebTransactionDetailWithContext - same as GetWebTransactionDetail, using ctx for cancellation and deadlines
+func (c *Client) GetWebTransactionDetailWithContext(ctx context.Context, testID, agentID, roundID int64) (*WebTransactionResults, error) {
+	return c.getWebTransactionResults(ctx, fmt.Sprintf("/web/web-transactions/%d/%d/%d", testID, agentID, roundID), nil)
+}
+
+func (c *Client) getWebTransactionResults(ctx context.Context, path string, opts *ResultsOptions) (*WebTransactionResults, error) {
+	results := WebTransactionResults{Results: []WebTransactionResult{}}
+	err := c.getResults(ctx, path, opts, func(resp *http.Response) (*ResultsPages, error) {
+		var target struct {
+			Web struct {
+				Test           GenericTest            `json:"test"`
+				WebTransaction []WebTransactionResult `json:"webTransaction"`
+			} `json:"web"`
+			Pages *ResultsPages `json:"pages"`
+		}
+		if err := c.decodeJSON(resp, &target); err != nil {
+			return nil, err
+		}
+		results.Test = target.Web.Test
+		for _, r := range target.Web.WebTransaction {
+			if opts.includes(r.AgentID, r.RoundID) {
+				results.Results = append(results.Results, r)
+			}
+		}
+		return target.Pages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
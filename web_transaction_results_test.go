@@ -0,0 +1,117 @@
+package thousandeyes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const webTransactionResultsOut = `{"web": {"test": {"testId": 1, "type": "web-transactions"}, "webTransaction": [
+	{"agentId": 10, "agentName": "Dallas", "roundId": 100, "responseTime": 120, "transactionTime": 3200,
+	 "completion": 100, "numSteps": 2, "numPages": 2,
+	 "steps": [{"stepNum": 1, "stepName": "Open home", "startTime": 0, "duration": 1200},
+	           {"stepNum": 2, "stepName": "Log in", "startTime": 1200, "duration": 2000}],
+	 "markers": [{"name": "Checkout", "startTime": 1200, "duration": 1900}]},
+	{"agentId": 20, "agentName": "London", "roundId": 100, "completion": 50, "numSteps": 2,
+	 "steps": [{"stepNum": 1, "duration": 1500}], "errorType": "Timeout",
+	 "errorDetails": "Step 2 timed out", "screenshotUrl": "https://app.thousandeyes.com/screenshot/1"}
+]}, "pages": {"current": 1}}`
+
+func TestClient_GetWebTransactionResults(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/web-transactions/1.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "1d", r.URL.Query().Get("window"))
+		_, _ = w.Write([]byte(webTransactionResultsOut))
+	})
+
+	res, err := client.GetWebTransactionResults(1, &ResultsOptions{Window: "1d"})
+	assert.Nil(t, err)
+	assert.Len(t, res.Results, 2)
+	first := res.Results[0]
+	assert.Equal(t, Float64(3200), first.TransactionTime)
+	assert.Equal(t, Float64(100), first.Completion)
+	assert.Equal(t, &WebTransactionMarker{Name: String("Checkout"), StartTime: Float64(1200), Duration: Float64(1900)}, first.Marker("Checkout"))
+	assert.Nil(t, first.Marker("Missing"))
+	second := res.Results[1]
+	assert.Equal(t, String("Timeout"), second.ErrorType)
+	assert.Equal(t, String("https://app.thousandeyes.com/screenshot/1"), second.ScreenshotURL)
+}
+
+func TestClient_GetWebTransactionDetail(t *testing.T) {
+	setup()
+	defer teardown()
+	var client = &Client{APIEndpoint: server.URL, AuthToken: "foo"}
+	mux.HandleFunc("/web/web-transactions/1/10/100.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(webTransactionResultsOut))
+	})
+
+	res, err := client.GetWebTransactionDetail(1, 10, 100)
+	assert.Nil(t, err)
+	assert.Len(t, res.Results, 2)
+}
+
+func TestTransactionMarkerNames(t *testing.T) {
+	test := WebTransaction{TransactionScript: String(`
+		import {markers} from 'thousandeyes';
+		runScript();
+		async function runScript() {
+			markers.start('Open home');
+			await driver.get('https://example.com/?q=//x');
+			markers.stop('Open home');
+			// markers.start('Old step');
+			/* markers.start('Disabled');
+			   markers.stop('Disabled'); */
+			markers.start("Log in");
+			await click(By.id('login'));
+			markers.stop("Log in");
+			markers.start('Open home');
+			markers.start(` + "`Check out`" + `);
+		}`)}
+	assert.Equal(t, []string{"Open home", "Log in", "Check out"}, test.MarkerNames())
+	assert.Equal(t, []string{}, TransactionMarkerNames(""))
+	assert.Equal(t, []string{}, TransactionMarkerNames("// markers.start('x')"))
+}
+
+func TestWebTransactionResult_AlignMarkers(t *testing.T) {
+	result := WebTransactionResult{Markers: &[]WebTransactionMarker{
+		{Name: String("Log in"), Duration: Float64(2000)},
+		{Name: String("Open home"), Duration: Float64(1200)},
+	}}
+	aligned := result.AlignMarkers([]string{"Open home", "Log in", "Check out"})
+	assert.Len(t, aligned, 3)
+	assert.Equal(t, "Open home", aligned[0].Name)
+	assert.Equal(t, Float64(1200), aligned[0].Marker.Duration)
+	assert.Equal(t, Float64(2000), aligned[1].Marker.Duration)
+	assert.Equal(t, "Check out", aligned[2].Name)
+	assert.Nil(t, aligned[2].Marker)
+
+	aligned = WebTransactionResult{}.AlignMarkers([]string{"Open home"})
+	assert.Equal(t, []WebTransactionNamedMarker{{Name: "Open home"}}, aligned)
+}
+
+func TestWebTransactionResult_AlignSteps(t *testing.T) {
+	result := WebTransactionResult{Steps: &[]WebTransactionStep{
+		{StepNum: Int(2), StepName: String("Log in"), Duration: Float64(2000)},
+		{StepNum: Int(1), StepName: String("Open home"), Duration: Float64(1200)},
+		{StepNum: Int(3), Duration: Float64(300)},
+		{StepNum: Int(9), StepName: String("Unknown"), Duration: Float64(1)},
+	}}
+	aligned := result.AlignSteps([]string{"Open home", "Log in", "Search", "Check out"})
+	assert.Len(t, aligned, 4)
+	assert.Equal(t, "Open home", aligned[0].Name)
+	assert.Equal(t, Float64(1200), aligned[0].Step.Duration)
+	assert.Equal(t, Float64(2000), aligned[1].Step.Duration)
+	// Unnamed results match on their number
+	assert.Equal(t, Float64(300), aligned[2].Step.Duration)
+	// Steps missing from the results are nil
+	assert.Equal(t, "Check out", aligned[3].Name)
+	assert.Nil(t, aligned[3].Step)
+
+	aligned = WebTransactionResult{}.AlignSteps([]string{"Open home"})
+	assert.Equal(t, []WebTransactionNamedStep{{Name: "Open home"}}, aligned)
+}
@@ -0,0 +1,184 @@
+// Package webhook receives ThousandEyes alert notifications sent to
+// webhook integrations.  Handler validates and decodes the notifications,
+// then dispatches them to the registered callbacks.
+//
+//	h := &webhook.Handler{Secret: secret}
+//	h.OnTrigger(func(ctx context.Context, e webhook.Event) error {
+//		log.Printf("alert %d raised: %s", *e.Alert.AlertID, *e.Alert.RuleName)
+//		return nil
+//	})
+//	http.Handle("/thousandeyes", h)
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+// Types of webhook events
+const (
+	EventTrigger = "ALERT_NOTIFICATION_TRIGGER"
+	EventClear   = "ALERT_NOTIFICATION_CLEAR"
+	EventTest    = "WEBHOOK_TEST"
+)
+
+// DefaultSecretHeader carries the shared secret when SecretHeader is empty
+const DefaultSecretHeader = "X-Webhook-Secret"
+
+// defaultMaxBodyBytes limits the size of notifications when MaxBodyBytes
+// is 0
+const defaultMaxBodyBytes = 1 << 20
+
+// Event - a webhook notification.  Alert is nil for test notifications.
+type Event struct {
+	EventID   string              `json:"eventId"`
+	EventType string              `json:"eventType"`
+	Alert     *thousandeyes.Alert `json:"alert,omitempty"`
+}
+
+// Callback - receives events.  An error makes the handler respond with
+// HTTP 500, so that the notification may be sent again.
+type Callback func(ctx context.Context, e Event) error
+
+// Handler - http.Handler receiving webhook notifications.  Callbacks may
+// be registered while the handler serves requests.
+type Handler struct {
+	// Secret, when set, must be sent in the SecretHeader request header
+	Secret string
+	// SecretHeader is the header carrying Secret, DefaultSecretHeader by
+	// default
+	SecretHeader string
+	// Username and Password, when set, are required as basic auth
+	Username string
+	Password string
+	// MaxBodyBytes limits the size of notifications, 1MB by default
+	MaxBodyBytes int64
+
+	mu        sync.RWMutex
+	callbacks map[string][]Callback
+	all       []Callback
+}
+
+// OnTrigger registers fn for alerts being raised
+func (h *Handler) OnTrigger(fn Callback) {
+	h.on(EventTrigger, fn)
+}
+
+// OnClear registers fn for alerts being cleared
+func (h *Handler) OnClear(fn Callback) {
+	h.on(EventClear, fn)
+}
+
+// OnTest registers fn for the test notifications sent when configuring
+// the webhook
+func (h *Handler) OnTest(fn Callback) {
+	h.on(EventTest, fn)
+}
+
+// OnEvent registers fn for every event, including unknown types
+func (h *Handler) OnEvent(fn Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.all = append(h.all, fn)
+}
+
+func (h *Handler) on(eventType string, fn Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.callbacks == nil {
+		h.callbacks = map[string][]Callback{}
+	}
+	h.callbacks[eventType] = append(h.callbacks[eventType], fn)
+}
+
+// ServeHTTP - Satisfying the http.Handler interface
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		if h.Username != "" || h.Password != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="thousandeyes"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	e, err := h.decode(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.dispatch(r.Context(), e); err != nil {
+		http.Error(w, "could not handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorized checks the shared secret and basic auth credentials
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.Secret != "" {
+		header := h.SecretHeader
+		if header == "" {
+			header = DefaultSecretHeader
+		}
+		if !equal(r.Header.Get(header), h.Secret) {
+			return false
+		}
+	}
+	if h.Username != "" || h.Password != "" {
+		username, password, ok := r.BasicAuth()
+		if !ok || !equal(username, h.Username) || !equal(password, h.Password) {
+			return false
+		}
+	}
+	return true
+}
+
+// equal compares secrets in constant time
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// decode reads and validates the event of a request
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request) (Event, error) {
+	limit := h.MaxBodyBytes
+	if limit <= 0 {
+		limit = defaultMaxBodyBytes
+	}
+	var e Event
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	if err := decoder.Decode(&e); err != nil {
+		return e, errors.New("invalid event: " + err.Error())
+	}
+	if e.EventType == "" {
+		return e, errors.New("invalid event: missing eventType")
+	}
+	if (e.EventType == EventTrigger || e.EventType == EventClear) && (e.Alert == nil || e.Alert.AlertID == nil) {
+		return e, errors.New("invalid event: missing alert")
+	}
+	return e, nil
+}
+
+// dispatch passes e to the callbacks of its type, then to those of every
+// event, stopping at the first error
+func (h *Handler) dispatch(ctx context.Context, e Event) error {
+	h.mu.RLock()
+	callbacks := append(append([]Callback{}, h.callbacks[e.EventType]...), h.all...)
+	h.mu.RUnlock()
+	for _, fn := range callbacks {
+		if err := fn(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
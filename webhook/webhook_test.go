@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thousandeyes/thousandeyes-sdk-go/v2"
+)
+
+const triggerPayload = `{
+	"eventId": "1234-5678",
+	"eventType": "ALERT_NOTIFICATION_TRIGGER",
+	"alert": {
+		"alertId": 42, "testId": 1, "testName": "web", "active": 1, "ruleName": "Latency",
+		"ruleExpression": "((avgLatency >= 100 ms))", "dateStart": "2022-03-01 10:00:00",
+		"violationCount": 1, "type": "HTTP Server",
+		"agents": [{"agentId": 10, "agentName": "Dallas", "metricsAtStart": "Latency: 120 ms"}],
+		"monitors": [{"monitorId": 5, "monitorName": "Seattle-3"}]
+	}
+}`
+
+func post(h http.Handler, body string, setup func(*http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if setup != nil {
+		setup(req)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Dispatch(t *testing.T) {
+	h := &Handler{}
+	var triggered, cleared, all []Event
+	h.OnTrigger(func(ctx context.Context, e Event) error {
+		triggered = append(triggered, e)
+		return nil
+	})
+	h.OnClear(func(ctx context.Context, e Event) error {
+		cleared = append(cleared, e)
+		return nil
+	})
+	h.OnEvent(func(ctx context.Context, e Event) error {
+		all = append(all, e)
+		return nil
+	})
+
+	rec := post(h, triggerPayload, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, triggered, 1)
+	assert.Len(t, cleared, 0)
+	assert.Len(t, all, 1)
+	e := triggered[0]
+	assert.Equal(t, "1234-5678", e.EventID)
+	assert.Equal(t, EventTrigger, e.EventType)
+	assert.Equal(t, thousandeyes.Int64(42), e.Alert.AlertID)
+	assert.Equal(t, &[]thousandeyes.Agent{{AgentID: thousandeyes.Int64(10), AgentName: thousandeyes.String("Dallas")}}, e.Alert.Agents)
+	assert.Equal(t, &[]thousandeyes.Monitor{{MonitorID: thousandeyes.Int64(5), MonitorName: thousandeyes.String("Seattle-3")}}, e.Alert.Monitors)
+
+	rec = post(h, `{"eventId": "1", "eventType": "ALERT_NOTIFICATION_CLEAR", "alert": {"alertId": 42, "active": 0}}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, cleared, 1)
+
+	rec = post(h, `{"eventId": "2", "eventType": "WEBHOOK_TEST"}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, all, 3)
+	assert.Nil(t, all[2].Alert)
+}
+
+func TestHandler_Invalid(t *testing.T) {
+	h := &Handler{}
+	called := false
+	h.OnEvent(func(ctx context.Context, e Event) error {
+		called = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	for _, body := range []string{
+		`not json`,
+		`{"eventId": "1"}`,
+		`{"eventId": "1", "eventType": "ALERT_NOTIFICATION_TRIGGER"}`,
+		`{"eventId": "1", "eventType": "ALERT_NOTIFICATION_CLEAR", "alert": {}}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, post(h, body, nil).Code, body)
+	}
+
+	h.MaxBodyBytes = 10
+	assert.Equal(t, http.StatusBadRequest, post(h, triggerPayload, nil).Code)
+	assert.False(t, called)
+}
+
+func TestHandler_CallbackError(t *testing.T) {
+	h := &Handler{}
+	second := false
+	h.OnTrigger(func(ctx context.Context, e Event) error {
+		return errors.New("failure")
+	})
+	h.OnEvent(func(ctx context.Context, e Event) error {
+		second = true
+		return nil
+	})
+
+	rec := post(h, triggerPayload, nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.False(t, second)
+}
+
+func TestHandler_Secret(t *testing.T) {
+	h := &Handler{Secret: "s3cret"}
+	assert.Equal(t, http.StatusUnauthorized, post(h, triggerPayload, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, post(h, triggerPayload, func(r *http.Request) {
+		r.Header.Set(DefaultSecretHeader, "wrong")
+	}).Code)
+	assert.Equal(t, http.StatusOK, post(h, triggerPayload, func(r *http.Request) {
+		r.Header.Set(DefaultSecretHeader, "s3cret")
+	}).Code)
+
+	h.SecretHeader = "X-Token"
+	assert.Equal(t, http.StatusOK, post(h, triggerPayload, func(r *http.Request) {
+		r.Header.Set("X-Token", "s3cret")
+	}).Code)
+}
+
+func TestHandler_BasicAuth(t *testing.T) {
+	h := &Handler{Username: "te", Password: "pass"}
+	rec := post(h, triggerPayload, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Basic realm="thousandeyes"`, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, post(h, triggerPayload, func(r *http.Request) {
+		r.SetBasicAuth("te", "wrong")
+	}).Code)
+	assert.Equal(t, http.StatusOK, post(h, triggerPayload, func(r *http.Request) {
+		r.SetBasicAuth("te", "pass")
+	}).Code)
+}